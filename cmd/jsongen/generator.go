@@ -0,0 +1,582 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const sdbImport = "github.com/seambiz/seambiz/sdb"
+
+type kind int
+
+const (
+	kindUnsupported kind = iota
+	kindString
+	kindInt
+	kindInt8
+	kindInt16
+	kindInt32
+	kindInt64
+	kindUint
+	kindUint8
+	kindUint16
+	kindUint32
+	kindUint64
+	kindFloat32
+	kindFloat64
+	kindBool
+	kindTime
+	kindRaw
+	kindStruct
+)
+
+// +gochecknoglobals
+var basicKinds = map[string]kind{
+	"string":  kindString,
+	"int":     kindInt,
+	"int8":    kindInt8,
+	"int16":   kindInt16,
+	"int32":   kindInt32,
+	"rune":    kindInt32,
+	"int64":   kindInt64,
+	"uint":    kindUint,
+	"uint8":   kindUint8,
+	"byte":    kindUint8,
+	"uint16":  kindUint16,
+	"uint32":  kindUint32,
+	"uint64":  kindUint64,
+	"float32": kindFloat32,
+	"float64": kindFloat64,
+	"bool":    kindBool,
+}
+
+// writer names the JsonBuffer methods for a kind: keyed writes "key":value,
+// value writes the value only. param is the Go type the methods expect.
+type writer struct {
+	keyed string
+	value string
+	param string
+}
+
+// +gochecknoglobals
+var writers = map[kind]writer{
+	kindString:  {keyed: "JS", value: "JSe", param: "string"},
+	kindInt:     {keyed: "JD", value: "D", param: "int"},
+	kindInt8:    {keyed: "JD", value: "D", param: "int"},
+	kindInt16:   {keyed: "JD", value: "D", param: "int"},
+	kindInt32:   {keyed: "JD", value: "D", param: "int"},
+	kindInt64:   {keyed: "JD64", value: "D64", param: "int64"},
+	kindUint:    {keyed: "JDu", value: "Du", param: "uint"},
+	kindUint8:   {keyed: "JDu", value: "Du", param: "uint"},
+	kindUint16:  {keyed: "JDu", value: "Du", param: "uint"},
+	kindUint32:  {keyed: "JDu", value: "Du", param: "uint"},
+	kindUint64:  {keyed: "JD64u", value: "D64u", param: "uint64"},
+	kindFloat32: {keyed: "JF", value: "F", param: "float32"},
+	kindFloat64: {keyed: "JF64", value: "F64", param: "float64"},
+	kindBool:    {keyed: "JB", param: "bool"},
+	kindTime:    {keyed: "JT", value: "T"},
+	kindRaw:     {keyed: "JRaw"},
+}
+
+// field is a JSON object member, embedded struct fields are already flattened.
+type field struct {
+	key       string
+	expr      string
+	typ       ast.Expr
+	omitEmpty bool
+	asString  bool
+	guards    []string
+	depth     int
+}
+
+type generator struct {
+	pkgName string
+	types   map[string]*ast.TypeSpec
+	buf     bytes.Buffer
+}
+
+// Generate returns the formatted source of MarshalJsonBuffer methods for the
+// struct types typeNames declared in the package in dir.
+func Generate(dir string, typeNames []string) ([]byte, error) {
+	g := &generator{types: map[string]*ast.TypeSpec{}}
+	if err := g.parseDir(dir); err != nil {
+		return nil, err
+	}
+
+	g.printf("// Code generated by jsongen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkgName)
+	g.printf("import %q\n", sdbImport)
+
+	for _, name := range typeNames {
+		name = strings.TrimSpace(name)
+		spec, ok := g.types[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in %s", name, dir)
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a struct", name)
+		}
+		fields, err := g.collect(st, "t", nil, 0, map[string]bool{name: true})
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		if err := g.method(name, dominantFields(fields)); err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) parseDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_jsongen.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		g.pkgName = f.Name.Name
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.types[ts.Name.Name] = ts
+			}
+		}
+	}
+	if g.pkgName == "" {
+		return fmt.Errorf("no go files found in %s", dir)
+	}
+	return nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// collect returns the JSON members of st. Embedded structs of this package
+// without json name are flattened like encoding/json does.
+func (g *generator) collect(st *ast.StructType, prefix string, guards []string, depth int, visited map[string]bool) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			raw, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(raw).Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		key, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			key, opts = tag[:i], tag[i+1:]
+		}
+
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+		if len(f.Names) == 0 {
+			typ, ptr := f.Type, false
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ, ptr = star.X, true
+			}
+			var typeName string
+			switch t := typ.(type) {
+			case *ast.Ident:
+				typeName = t.Name
+			case *ast.SelectorExpr:
+				typeName = t.Sel.Name
+			}
+
+			if ident, ok := typ.(*ast.Ident); ok && key == "" && !visited[typeName] {
+				if spec, ok := g.types[ident.Name]; ok {
+					if sub, ok := spec.Type.(*ast.StructType); ok {
+						expr := prefix + "." + typeName
+						subGuards := guards
+						if ptr {
+							subGuards = append(append([]string(nil), guards...), expr)
+						}
+						subVisited := map[string]bool{typeName: true}
+						for k := range visited {
+							subVisited[k] = true
+						}
+						embedded, err := g.collect(sub, expr, subGuards, depth+1, subVisited)
+						if err != nil {
+							return nil, err
+						}
+						fields = append(fields, embedded...)
+						continue
+					}
+				}
+			}
+			names = append(names, typeName)
+		}
+
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+			k := key
+			if k == "" {
+				k = name
+			}
+			if !validKey(k) {
+				return nil, fmt.Errorf("field %s: unsupported json key %q", name, k)
+			}
+			fields = append(fields, field{
+				key:       k,
+				expr:      prefix + "." + name,
+				typ:       f.Type,
+				omitEmpty: hasOption(opts, "omitempty"),
+				asString:  hasOption(opts, "string"),
+				guards:    guards,
+				depth:     depth,
+			})
+		}
+	}
+	return fields, nil
+}
+
+// dominantFields drops fields hidden by a shallower field with the same key.
+// Fields on the same depth with the same key cancel each other out.
+func dominantFields(fields []field) []field {
+	minDepth := map[string]int{}
+	count := map[string]int{}
+	for _, f := range fields {
+		d, ok := minDepth[f.key]
+		switch {
+		case !ok || f.depth < d:
+			minDepth[f.key] = f.depth
+			count[f.key] = 1
+		case f.depth == d:
+			count[f.key]++
+		}
+	}
+
+	out := fields[:0]
+	for _, f := range fields {
+		if f.depth == minDepth[f.key] && count[f.key] == 1 {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func hasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validKey reports whether key can be written by the JsonBuffer writers without escaping.
+func validKey(key string) bool {
+	for _, c := range key {
+		if c < 0x20 || c == '"' || c == '\\' || c == '<' || c == '\'' {
+			return false
+		}
+	}
+	return key != ""
+}
+
+func (g *generator) method(name string, fields []field) error {
+	g.printf("\n// MarshalJsonBuffer writes %s as JSON object to buf.\n", name)
+	g.printf("func (t *%s) MarshalJsonBuffer(buf *sdb.JsonBuffer) {\n", name)
+	g.printf("buf.S(\"{\")\n")
+	if len(fields) > 0 {
+		g.printf("sep := \"\"\n")
+	}
+	for _, f := range fields {
+		for _, guard := range f.guards {
+			g.printf("if %s != nil {\n", guard)
+		}
+		if err := g.field(f); err != nil {
+			return fmt.Errorf("field %s: %w", f.expr, err)
+		}
+		for range f.guards {
+			g.printf("}\n")
+		}
+	}
+	g.printf("buf.S(\"}\")\n")
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) field(f field) error {
+	key := strconv.Quote(f.key)
+
+	if star, ok := f.typ.(*ast.StarExpr); ok {
+		value := "*" + f.expr
+		if g.kind(star.X) == kindStruct {
+			value = f.expr
+		}
+		if f.omitEmpty {
+			g.printf("if %s != nil {\n", f.expr)
+		} else {
+			g.printf("if %s == nil {\n", f.expr)
+			g.printf("buf.JNull(sep, %s)\n", key)
+			g.printf("} else {\n")
+		}
+		if err := g.keyed(f, key, value, star.X); err != nil {
+			return err
+		}
+		if f.omitEmpty {
+			g.printf("sep = \",\"\n")
+			g.printf("}\n")
+		} else {
+			g.printf("}\n")
+			g.printf("sep = \",\"\n")
+		}
+		return nil
+	}
+
+	cond := ""
+	if f.omitEmpty {
+		cond = g.nonEmpty(f.expr, f.typ)
+	}
+	if cond != "" {
+		g.printf("if %s {\n", cond)
+	}
+	if err := g.keyed(f, key, f.expr, f.typ); err != nil {
+		return err
+	}
+	g.printf("sep = \",\"\n")
+	if cond != "" {
+		g.printf("}\n")
+	}
+	return nil
+}
+
+// nonEmpty returns the condition for writing an omitempty field, empty for types never omitted.
+func (g *generator) nonEmpty(expr string, typ ast.Expr) string {
+	switch typ.(type) {
+	case *ast.ArrayType, *ast.MapType:
+		return "len(" + expr + ") != 0"
+	}
+	switch g.kind(typ) {
+	case kindString:
+		return expr + ` != ""`
+	case kindBool:
+		return expr
+	case kindTime:
+		return "!" + expr + ".IsZero()"
+	case kindRaw:
+		return "len(" + expr + ") != 0"
+	case kindStruct, kindUnsupported:
+		return ""
+	}
+	return expr + " != 0"
+}
+
+// keyed writes "key":value for the non pointer value expr of type typ.
+func (g *generator) keyed(f field, key, expr string, typ ast.Expr) error {
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		return g.slice(key, expr, t)
+	case *ast.MapType:
+		return g.mapping(key, expr, t)
+	}
+
+	k := g.kind(typ)
+	switch k {
+	case kindUnsupported:
+		return fmt.Errorf("unsupported type %s", exprString(typ))
+	case kindStruct:
+		g.printf("buf.S(sep)\n")
+		g.printf("buf.S(%s)\n", strconv.Quote(strconv.Quote(f.key)+":"))
+		g.printf("%s.MarshalJsonBuffer(buf)\n", expr)
+		return nil
+	}
+
+	w := writers[k]
+	if f.asString && k != kindString && w.param != "" {
+		// the string option encodes numbers and booleans inside a JSON string
+		g.printf("buf.S(sep)\n")
+		g.printf("buf.S(%s)\n", strconv.Quote(strconv.Quote(f.key)+`:"`))
+		g.value(k, expr, typ)
+		g.printf("buf.S(%s)\n", strconv.Quote(`"`))
+		return nil
+	}
+	g.printf("buf.%s(sep, %s, %s)\n", w.keyed, key, g.convert(k, expr, typ))
+	return nil
+}
+
+// slice writes []int, []string and []float64 with the array writers,
+// other element types element by element.
+func (g *generator) slice(key, expr string, t *ast.ArrayType) error {
+	if t.Len != nil {
+		return fmt.Errorf("unsupported array type %s", exprString(t))
+	}
+	if ident, ok := t.Elt.(*ast.Ident); ok {
+		switch ident.Name {
+		case "int":
+			g.printf("buf.JArrayInt(sep, %s, %s)\n", key, expr)
+			return nil
+		case "string":
+			g.printf("buf.JArrayString(sep, %s, %s)\n", key, expr)
+			return nil
+		case "float64":
+			g.printf("buf.JArrayFloat(sep, %s, %s)\n", key, expr)
+			return nil
+		case "byte", "uint8":
+			return fmt.Errorf("unsupported type %s", exprString(t))
+		}
+	}
+
+	elem, ptr := t.Elt, false
+	if star, ok := elem.(*ast.StarExpr); ok {
+		elem, ptr = star.X, true
+	}
+	k := g.kind(elem)
+	if k == kindUnsupported || k == kindRaw {
+		return fmt.Errorf("unsupported type %s", exprString(t))
+	}
+
+	g.printf("if %s == nil {\n", expr)
+	g.printf("buf.JNull(sep, %s)\n", key)
+	g.printf("} else {\n")
+	g.printf("buf.S(sep)\n")
+	unquoted, _ := strconv.Unquote(key)
+	g.printf("buf.S(%s)\n", strconv.Quote(strconv.Quote(unquoted)+":["))
+	g.printf("for i, v := range %s {\n", expr)
+	g.printf("if i > 0 {\n")
+	g.printf("buf.S(\",\")\n")
+	g.printf("}\n")
+	if ptr {
+		value := "*v"
+		if k == kindStruct {
+			value = "v"
+		}
+		g.printf("if v == nil {\n")
+		g.printf("buf.S(\"null\")\n")
+		g.printf("} else {\n")
+		g.value(k, value, elem)
+		g.printf("}\n")
+	} else {
+		g.value(k, "v", elem)
+	}
+	g.printf("}\n")
+	g.printf("buf.S(\"]\")\n")
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) mapping(key, expr string, t *ast.MapType) error {
+	if ident, ok := t.Key.(*ast.Ident); !ok || ident.Name != "string" {
+		return fmt.Errorf("unsupported map key %s", exprString(t.Key))
+	}
+	if ident, ok := t.Value.(*ast.Ident); ok {
+		switch ident.Name {
+		case "string":
+			g.printf("buf.JMapString(sep, %s, %s)\n", key, expr)
+			return nil
+		case "int":
+			g.printf("buf.JMapInt(sep, %s, %s)\n", key, expr)
+			return nil
+		case "float64":
+			g.printf("buf.JMapFloat(sep, %s, %s)\n", key, expr)
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported type %s", exprString(t))
+}
+
+// value writes the value expr without key.
+func (g *generator) value(k kind, expr string, typ ast.Expr) {
+	switch k {
+	case kindStruct:
+		g.printf("%s.MarshalJsonBuffer(buf)\n", expr)
+	case kindBool:
+		g.printf("if %s {\n", expr)
+		g.printf("buf.S(\"true\")\n")
+		g.printf("} else {\n")
+		g.printf("buf.S(\"false\")\n")
+		g.printf("}\n")
+	default:
+		g.printf("buf.%s(%s)\n", writers[k].value, g.convert(k, expr, typ))
+	}
+}
+
+// convert wraps expr into a conversion, if its type differs from the writer parameter.
+func (g *generator) convert(k kind, expr string, typ ast.Expr) string {
+	param := writers[k].param
+	if param == "" {
+		return expr
+	}
+	if ident, ok := typ.(*ast.Ident); ok && ident.Name == param {
+		return expr
+	}
+	return param + "(" + expr + ")"
+}
+
+// kind classifies typ. Named types of this package are resolved to their
+// underlying type, unknown named types are expected to implement MarshalJsonBuffer.
+func (g *generator) kind(typ ast.Expr) kind {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if k, ok := basicKinds[t.Name]; ok {
+			return k
+		}
+		spec, ok := g.types[t.Name]
+		if !ok {
+			return kindUnsupported
+		}
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			return kindStruct
+		}
+		if ident, ok := spec.Type.(*ast.Ident); ok && ident.Name != t.Name {
+			if k := g.kind(ident); k != kindStruct {
+				return k
+			}
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			switch pkg.Name + "." + t.Sel.Name {
+			case "time.Time":
+				return kindTime
+			case "json.RawMessage":
+				return kindRaw
+			}
+		}
+		return kindStruct
+	}
+	return kindUnsupported
+}
+
+func exprString(typ ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), typ)
+	return buf.String()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		dir    string
+		types  []string
+		golden string
+	}{
+		{
+			name:   "models",
+			dir:    "testdata/models",
+			types:  []string{"User", "Address"},
+			golden: "testdata/models.golden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generate(tt.dir, tt.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *update {
+				if err := os.WriteFile(tt.golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(tt.golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("generated code differs from %s, run go test -update\ngot:\n%s", filepath.Base(tt.golden), got)
+			}
+		})
+	}
+}
+
+func TestGenerateErr(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []string
+	}{
+		{name: "unknown type", types: []string{"Missing"}},
+		{name: "not a struct", types: []string{"Status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate("testdata/models", tt.types); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
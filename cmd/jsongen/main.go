@@ -0,0 +1,55 @@
+// jsongen generates reflection free MarshalJsonBuffer methods for structs.
+//
+// Usage:
+//
+//	//go:generate go run github.com/seambiz/seambiz/cmd/jsongen -type User,Address
+//
+// The generated methods write the struct as JSON object to a *sdb.JsonBuffer
+// using its writers (JS, JD, JT, ...). The json struct tags are honoured
+// including omitempty, string and "-". Embedded structs declared in the same
+// package are flattened, pointers are written as null when nil.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_jsongen.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: jsongen -type T[,T...] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	types := strings.Split(*typeNames, ",")
+	src, err := Generate(dir, types)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "jsongen:", err)
+		os.Exit(1)
+	}
+
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(types[0])+"_jsongen.go")
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "jsongen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Status int
+
+type Base struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type Audit struct {
+	Editor string `json:"editor,omitempty"`
+	ID     int    `json:"audit_id"`
+}
+
+type Address struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip,omitempty"`
+}
+
+type User struct {
+	Base
+	*Audit
+	Name      string            `json:"name"`
+	Email     string            `json:"email,omitempty"`
+	Age       int8              `json:"age"`
+	Balance   float64           `json:"balance"`
+	Rate      float32           `json:"rate,omitempty"`
+	Admin     bool              `json:"admin"`
+	Visits    uint64            `json:"visits,string"`
+	Status    Status            `json:"status"`
+	Nick      *string           `json:"nick"`
+	Parent    *int64            `json:"parent,omitempty"`
+	Address   Address           `json:"address"`
+	Billing   *Address          `json:"billing,omitempty"`
+	Tags      []string          `json:"tags"`
+	Scores    []int             `json:"scores,omitempty"`
+	Flags     []bool            `json:"flags"`
+	Others    []*Address        `json:"others"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Extra     json.RawMessage   `json:"extra"`
+	LastLogin time.Time         `json:"last_login,omitempty"`
+	Internal  string            `json:"-"`
+	Untagged  uint16
+	secret    string
+}
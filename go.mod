@@ -1,6 +1,6 @@
 module github.com/seambiz/seambiz
 
-go 1.15
+go 1.20
 
 require (
 	github.com/fatih/structs v1.1.0
@@ -0,0 +1,302 @@
+package param
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Sources of a parameter, also used as struct tag names by Bind
+const (
+	SourcePath   = "path"
+	SourceQuery  = "query"
+	SourceForm   = "form"
+	SourceHeader = "header"
+	SourceCookie = "cookie"
+)
+
+var errBindTarget = errors.New("param: Bind needs a non-nil pointer to a struct")
+
+// Bind fills the struct pointed to by dst from parameters named by the struct tags
+// `path:"id"`, `query:"limit"`, `form:"name"`, `header:"X-Request-Id"` and `cookie:"session"`.
+// Fields without tag are skipped, embedded structs are bound as well. Nil embedded struct
+// pointers are allocated.
+//
+// Supported are the types of Path and slices of them for all sources but path, which are
+// read like DefaultListOptions. Missing parameters are an error for scalar fields, pointer
+// fields are set to nil and slices to nil instead.
+// Constraints are declared with the validate tag, see TagValidate. All invalid fields are
+// returned as MultiError.
+func Bind(r *http.Request, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errBindTarget
+	}
+
+	b := binder{r: r}
+	if err := b.bindStruct(rv.Elem()); err != nil {
+		return err
+	}
+	if len(b.errs) > 0 {
+		return b.errs
+	}
+	return nil
+}
+
+type binder struct {
+	r     *http.Request
+	query url.Values
+	errs  MultiError
+}
+
+// bindSources in the order the struct tags are looked up
+// nolint[gochecknoblobals]
+var bindSources = []string{SourcePath, SourceQuery, SourceForm, SourceHeader, SourceCookie}
+
+func (b *binder) bindStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := b.bindStruct(v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct {
+			fv := v.Field(i)
+			if fv.IsNil() {
+				if !fv.CanSet() {
+					return fmt.Errorf("param: Bind: cannot allocate unexported embedded %s", f.Type)
+				}
+				fv.Set(reflect.New(f.Type.Elem()))
+			}
+			if err := b.bindStruct(fv.Elem()); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		var source, key string
+		for _, src := range bindSources {
+			if key = f.Tag.Get(src); key != "" {
+				source = src
+				break
+			}
+		}
+		if key == "" || key == "-" {
+			continue
+		}
+
+		if err := b.bindField(v.Field(i), f, source, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *binder) lookup(source string) (lookup, error) {
+	switch source {
+	case SourcePath:
+		return func(key string) ([]string, bool) {
+			v := pathValue(b.r, key)
+			return []string{v}, v != ""
+		}, nil
+	case SourceForm:
+		if err := parseForm(b.r); err != nil {
+			return nil, err
+		}
+		return valuesLookup(b.r.PostForm), nil
+	case SourceHeader:
+		return headerLookup(b.r), nil
+	case SourceCookie:
+		return cookieLookup(b.r), nil
+	}
+	if b.query == nil {
+		b.query = b.r.URL.Query()
+	}
+	return valuesLookup(b.query), nil
+}
+
+func (b *binder) values(source, key string, list bool) ([]string, error) {
+	get, err := b.lookup(source)
+	if err != nil {
+		return nil, err
+	}
+	if !list {
+		values, ok := get(key)
+		if !ok {
+			return nil, nil
+		}
+		return values, nil
+	}
+
+	items, _ := splitList(get, key, DefaultListOptions)
+	return items, nil
+}
+
+// dedupe removes duplicate items and keeps the first occurrence
+func dedupe(items []string) []string {
+	seen := make(map[string]struct{}, len(items))
+	out := items[:0:0]
+	for _, item := range items {
+		if _, dup := seen[item]; !dup {
+			seen[item] = struct{}{}
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func (b *binder) bindField(v reflect.Value, f reflect.StructField, source, key string) error {
+	t := f.Type
+	isSlice := t.Kind() == reflect.Slice && (t.Elem().Kind() != reflect.Uint8 || isText(t.Elem()))
+	elem := t
+	if isSlice || t.Kind() == reflect.Ptr {
+		elem = t.Elem()
+	}
+	if !isScalar(elem.Kind()) && !isText(elem) || isSlice && source == SourcePath {
+		return fmt.Errorf("param: Bind: unsupported type %s of field %s", t, f.Name)
+	}
+
+	constraints, err := parseConstraints(f.Tag.Get(TagValidate))
+	if err != nil {
+		return err
+	}
+	typ := elem.Kind().String()
+	if isText(elem) {
+		typ = elem.String()
+	}
+
+	values, err := b.values(source, key, isSlice)
+	if err != nil {
+		b.errs = append(b.errs, &Error{Key: key, Source: source, Type: typ, Err: err})
+		return nil
+	}
+	if max := DefaultListOptions.MaxItems; isSlice && max > 0 && len(values) > max {
+		b.errs = append(b.errs, tooManyItems(source, key, typ, max).(*Error))
+		return nil
+	}
+	if isSlice && DefaultListOptions.Dedupe {
+		values = dedupe(values)
+	}
+	if len(values) == 0 {
+		switch {
+		case (isSlice || t.Kind() == reflect.Ptr) && !hasConstraint(constraints, "nonempty"):
+			v.Set(reflect.Zero(t))
+		default:
+			b.errs = append(b.errs, &Error{Key: key, Source: source, Type: typ, Err: ErrMissingParam})
+		}
+		return nil
+	}
+
+	switch {
+	case isSlice:
+		out := reflect.MakeSlice(t, len(values), len(values))
+		for i, s := range values {
+			if err := setScalar(out.Index(i), s, source); err != nil {
+				b.errs = append(b.errs, &Error{Key: key, Source: source, Value: s, Type: typ, Err: err})
+				return nil
+			}
+		}
+		v.Set(out)
+	case t.Kind() == reflect.Ptr:
+		p := reflect.New(elem)
+		if err := setScalar(p.Elem(), values[0], source); err != nil {
+			b.errs = append(b.errs, &Error{Key: key, Source: source, Value: values[0], Type: typ, Err: err})
+			return nil
+		}
+		v.Set(p)
+	default:
+		if err := setScalar(v, values[0], source); err != nil {
+			b.errs = append(b.errs, &Error{Key: key, Source: source, Value: values[0], Type: typ, Err: err})
+			return nil
+		}
+	}
+
+	if err := validate(source, key, v, constraints); err != nil {
+		b.errs = append(b.errs, err.(*Error))
+	}
+	return nil
+}
+
+func hasConstraint(constraints []Constraint, name string) bool {
+	for _, c := range constraints {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalar(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isText(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// setScalar parses s into v like the typed functions of this package, addressable values
+// implementing encoding.TextUnmarshaler are unmarshaled
+func setScalar(v reflect.Value, s string, source string) error {
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bits := v.Type().Bits()
+		if v.Kind() == reflect.Uint {
+			// uint has always been parsed with 32 bit
+			bits = 32
+		}
+		n, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		// replace + stripped out during url parse stage
+		if source == SourceQuery && strings.Contains(s, " ") {
+			s = strings.Replace(s, " ", "+", 1)
+		}
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
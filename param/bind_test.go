@@ -0,0 +1,173 @@
+package param
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+)
+
+func newBindRequest(t *testing.T, path map[string]string, queryString string) *http.Request {
+	t.Helper()
+
+	c := chi.NewRouteContext()
+	for k, v := range path {
+		c.URLParams.Add(k, v)
+	}
+	r := httptest.NewRequest("GET", "/?"+queryString, nil)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, c))
+}
+
+type bindPaging struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+type bindFilter struct {
+	bindPaging
+	ID       uint64    `path:"id"`
+	Name     string    `query:"name"`
+	Active   *bool     `query:"active"`
+	Min      *float64  `query:"min"`
+	Ratio    float32   `query:"ratio"`
+	Tags     []string  `query:"tag"`
+	IDs      []int16   `query:"ids"`
+	Weights  []float64 `query:"w"`
+	Ignored  string
+	Skipped  string `query:"-"`
+	internal string
+}
+
+func TestBind(t *testing.T) {
+	r := newBindRequest(t, map[string]string{"id": "18446744073709551615"},
+		"limit=50&offset=100&name=foo&active=true&ratio=1e+3&tag=a&tag=b&ids=1&ids=-2&w=0.5&Skipped=x")
+
+	var got bindFilter
+	if err := Bind(r, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	active := true
+	want := bindFilter{
+		bindPaging: bindPaging{Limit: 50, Offset: 100},
+		ID:         18446744073709551615,
+		Name:       "foo",
+		Active:     &active,
+		Ratio:      1000,
+		Tags:       []string{"a", "b"},
+		IDs:        []int16{1, -2},
+		Weights:    []float64{0.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestBind_Optional(t *testing.T) {
+	type optional struct {
+		Name  *string `query:"name"`
+		Count *uint8  `query:"count"`
+		Tags  []int   `query:"tag"`
+	}
+	r := newBindRequest(t, nil, "other=1")
+
+	got := optional{Tags: []int{1}, Name: new(string)}
+	if err := Bind(r, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != nil || got.Count != nil || got.Tags != nil {
+		t.Errorf("got %+v, want all nil", got)
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	r := newBindRequest(t, map[string]string{"id": "abc"}, "limit=ten&ids=1&ids=99999&active=maybe")
+
+	var got bindFilter
+	err := Bind(r, &got)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("Bind() error = %v, want ErrInvalidParam", err)
+	}
+
+	var bindErr MultiError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("Bind() error = %T, want MultiError", err)
+	}
+	// limit, offset (missing), id, ratio (missing), name (missing), active, ids
+	if len(bindErr) != 7 {
+		t.Errorf("got %d errors, want %d: %v", len(bindErr), 7, err)
+	}
+	if e := bindErr[0]; e.Key != "limit" || e.Source != SourceQuery || e.Value != "ten" || e.Type != "int" {
+		t.Errorf("unexpected first error %+v", e)
+	}
+	for _, key := range []string{`"limit"`, `"offset"`, `"id"`, `"active"`, `"ids"`, `"99999"`} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not mention %s", err.Error(), key)
+		}
+	}
+}
+
+func TestBind_InvalidTarget(t *testing.T) {
+	r := newBindRequest(t, nil, "")
+
+	var s struct{}
+	for _, dst := range []interface{}{nil, s, (*struct{})(nil), new(int)} {
+		if err := Bind(r, dst); err == nil || errors.Is(err, ErrInvalidParam) {
+			t.Errorf("Bind(%T) error = %v, want usage error", dst, err)
+		}
+	}
+
+	unsupported := struct {
+		M map[string]string `query:"m"`
+	}{}
+	if err := Bind(r, &unsupported); err == nil || errors.Is(err, ErrInvalidParam) {
+		t.Errorf("Bind(map field) error = %v, want usage error", err)
+	}
+
+	pathSlice := struct {
+		IDs []int `path:"ids"`
+	}{}
+	if err := Bind(r, &pathSlice); err == nil {
+		t.Error("Bind(path slice) expected error")
+	}
+}
+
+type BindPage struct {
+	Limit int `query:"limit"`
+}
+
+type bindPointerFilter struct {
+	*BindPage
+	ID int `query:"id"`
+}
+
+type bindUnexportedPointer struct {
+	*bindPaging
+}
+
+func TestBindEmbeddedPointer(t *testing.T) {
+	var dst bindPointerFilter
+	if err := Bind(newBindRequest(t, nil, "id=5&limit=10"), &dst); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if dst.BindPage == nil || dst.Limit != 10 || dst.ID != 5 {
+		t.Errorf("Bind() = %+v, %+v", dst, dst.BindPage)
+	}
+
+	page := &BindPage{}
+	dst = bindPointerFilter{BindPage: page}
+	if err := Bind(newBindRequest(t, nil, "id=5&limit=3"), &dst); err != nil || dst.BindPage != page || page.Limit != 3 {
+		t.Errorf("Bind() = %+v, %v, want the existing pointer filled", dst.BindPage, err)
+	}
+
+	var unexported bindUnexportedPointer
+	err := Bind(newBindRequest(t, nil, "limit=1"), &unexported)
+	if err == nil || errors.Is(err, ErrInvalidParam) {
+		t.Errorf("Bind() error = %v, want unsupported embedded pointer", err)
+	}
+}
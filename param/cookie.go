@@ -0,0 +1,91 @@
+package param
+
+import "net/http"
+
+// Cookie returns the cookie key as T, see Path for the supported types.
+// Cookies are read as sent by the client, without decoding.
+func Cookie[T any](r *http.Request, key string) (T, error) {
+	return first(parseList[T](cookieLookup(r), SourceCookie, key, ListOptions{}))
+}
+
+func cookieLookup(r *http.Request) lookup {
+	return func(key string) ([]string, bool) {
+		var values []string
+		for _, c := range r.Cookies() {
+			if c.Name == key {
+				values = append(values, c.Value)
+			}
+		}
+		return values, len(values) > 0
+	}
+}
+
+// CookieString returns a cookie with string type
+func CookieString(r *http.Request, key string) (string, error) {
+	return Cookie[string](r, key)
+}
+
+// CookieInt returns a cookie with int type
+func CookieInt(r *http.Request, key string) (int, error) {
+	return Cookie[int](r, key)
+}
+
+// CookieInt8 returns a cookie with int8 type
+func CookieInt8(r *http.Request, key string) (int8, error) {
+	return Cookie[int8](r, key)
+}
+
+// CookieInt16 returns a cookie with int16 type
+func CookieInt16(r *http.Request, key string) (int16, error) {
+	return Cookie[int16](r, key)
+}
+
+// CookieInt32 returns a cookie with int32 type
+func CookieInt32(r *http.Request, key string) (int32, error) {
+	return Cookie[int32](r, key)
+}
+
+// CookieInt64 returns a cookie with int64 type
+func CookieInt64(r *http.Request, key string) (int64, error) {
+	return Cookie[int64](r, key)
+}
+
+// CookieUint returns a cookie with uint type
+func CookieUint(r *http.Request, key string) (uint, error) {
+	return Cookie[uint](r, key)
+}
+
+// CookieUint8 returns a cookie with uint8 type
+func CookieUint8(r *http.Request, key string) (uint8, error) {
+	return Cookie[uint8](r, key)
+}
+
+// CookieUint16 returns a cookie with uint16 type
+func CookieUint16(r *http.Request, key string) (uint16, error) {
+	return Cookie[uint16](r, key)
+}
+
+// CookieUint32 returns a cookie with uint32 type
+func CookieUint32(r *http.Request, key string) (uint32, error) {
+	return Cookie[uint32](r, key)
+}
+
+// CookieUint64 returns a cookie with uint64 type
+func CookieUint64(r *http.Request, key string) (uint64, error) {
+	return Cookie[uint64](r, key)
+}
+
+// CookieBool returns a cookie with boolean type
+func CookieBool(r *http.Request, key string) (bool, error) {
+	return Cookie[bool](r, key)
+}
+
+// CookieFloat32 returns a cookie with float32 type
+func CookieFloat32(r *http.Request, key string) (float32, error) {
+	return Cookie[float32](r, key)
+}
+
+// CookieFloat64 returns a cookie with float64 type
+func CookieFloat64(r *http.Request, key string) (float64, error) {
+	return Cookie[float64](r, key)
+}
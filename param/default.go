@@ -0,0 +1,187 @@
+package param
+
+import "net/http"
+
+// hasQuery reports whether key is present in the query, even with an empty value
+func hasQuery(r *http.Request, key string) bool {
+	_, ok := r.URL.Query()[key]
+	return ok
+}
+
+// queryDefault returns def for an absent parameter. For an invalid one def is returned with the error.
+func queryDefault[T any](r *http.Request, key string, def T, get func(*http.Request, string) (T, error)) (T, error) {
+	if !hasQuery(r, key) {
+		return def, nil
+	}
+	v, err := get(r, key)
+	if err != nil {
+		return def, err
+	}
+	return v, nil
+}
+
+// queryOptional returns nil for an absent parameter.
+func queryOptional[T any](r *http.Request, key string, get func(*http.Request, string) (T, error)) (*T, error) {
+	if !hasQuery(r, key) {
+		return nil, nil
+	}
+	v, err := get(r, key)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// QueryStringDefault returns a query parameter with string type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryStringDefault(r *http.Request, key string, def string) (string, error) {
+	return queryDefault(r, key, def, QueryString)
+}
+
+// QueryIntDefault returns a query parameter with int type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryIntDefault(r *http.Request, key string, def int) (int, error) {
+	return queryDefault(r, key, def, QueryInt)
+}
+
+// QueryInt8Default returns a query parameter with int8 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryInt8Default(r *http.Request, key string, def int8) (int8, error) {
+	return queryDefault(r, key, def, QueryInt8)
+}
+
+// QueryInt16Default returns a query parameter with int16 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryInt16Default(r *http.Request, key string, def int16) (int16, error) {
+	return queryDefault(r, key, def, QueryInt16)
+}
+
+// QueryInt32Default returns a query parameter with int32 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryInt32Default(r *http.Request, key string, def int32) (int32, error) {
+	return queryDefault(r, key, def, QueryInt32)
+}
+
+// QueryInt64Default returns a query parameter with int64 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryInt64Default(r *http.Request, key string, def int64) (int64, error) {
+	return queryDefault(r, key, def, QueryInt64)
+}
+
+// QueryUintDefault returns a query parameter with uint type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryUintDefault(r *http.Request, key string, def uint) (uint, error) {
+	return queryDefault(r, key, def, QueryUint)
+}
+
+// QueryUint8Default returns a query parameter with uint8 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryUint8Default(r *http.Request, key string, def uint8) (uint8, error) {
+	return queryDefault(r, key, def, QueryUint8)
+}
+
+// QueryUint16Default returns a query parameter with uint16 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryUint16Default(r *http.Request, key string, def uint16) (uint16, error) {
+	return queryDefault(r, key, def, QueryUint16)
+}
+
+// QueryUint32Default returns a query parameter with uint32 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryUint32Default(r *http.Request, key string, def uint32) (uint32, error) {
+	return queryDefault(r, key, def, QueryUint32)
+}
+
+// QueryUint64Default returns a query parameter with uint64 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryUint64Default(r *http.Request, key string, def uint64) (uint64, error) {
+	return queryDefault(r, key, def, QueryUint64)
+}
+
+// QueryBoolDefault returns a query parameter with boolean type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryBoolDefault(r *http.Request, key string, def bool) (bool, error) {
+	return queryDefault(r, key, def, QueryBool)
+}
+
+// QueryFloat32Default returns a query parameter with float32 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryFloat32Default(r *http.Request, key string, def float32) (float32, error) {
+	return queryDefault(r, key, def, QueryFloat32)
+}
+
+// QueryFloat64Default returns a query parameter with float64 type or def, if it is absent.
+// If it is present, but invalid, def is returned with the error.
+func QueryFloat64Default(r *http.Request, key string, def float64) (float64, error) {
+	return queryDefault(r, key, def, QueryFloat64)
+}
+
+// QueryStringOptional returns a query parameter with string type or nil, if it is absent
+func QueryStringOptional(r *http.Request, key string) (*string, error) {
+	return queryOptional(r, key, QueryString)
+}
+
+// QueryIntOptional returns a query parameter with int type or nil, if it is absent
+func QueryIntOptional(r *http.Request, key string) (*int, error) {
+	return queryOptional(r, key, QueryInt)
+}
+
+// QueryInt8Optional returns a query parameter with int8 type or nil, if it is absent
+func QueryInt8Optional(r *http.Request, key string) (*int8, error) {
+	return queryOptional(r, key, QueryInt8)
+}
+
+// QueryInt16Optional returns a query parameter with int16 type or nil, if it is absent
+func QueryInt16Optional(r *http.Request, key string) (*int16, error) {
+	return queryOptional(r, key, QueryInt16)
+}
+
+// QueryInt32Optional returns a query parameter with int32 type or nil, if it is absent
+func QueryInt32Optional(r *http.Request, key string) (*int32, error) {
+	return queryOptional(r, key, QueryInt32)
+}
+
+// QueryInt64Optional returns a query parameter with int64 type or nil, if it is absent
+func QueryInt64Optional(r *http.Request, key string) (*int64, error) {
+	return queryOptional(r, key, QueryInt64)
+}
+
+// QueryUintOptional returns a query parameter with uint type or nil, if it is absent
+func QueryUintOptional(r *http.Request, key string) (*uint, error) {
+	return queryOptional(r, key, QueryUint)
+}
+
+// QueryUint8Optional returns a query parameter with uint8 type or nil, if it is absent
+func QueryUint8Optional(r *http.Request, key string) (*uint8, error) {
+	return queryOptional(r, key, QueryUint8)
+}
+
+// QueryUint16Optional returns a query parameter with uint16 type or nil, if it is absent
+func QueryUint16Optional(r *http.Request, key string) (*uint16, error) {
+	return queryOptional(r, key, QueryUint16)
+}
+
+// QueryUint32Optional returns a query parameter with uint32 type or nil, if it is absent
+func QueryUint32Optional(r *http.Request, key string) (*uint32, error) {
+	return queryOptional(r, key, QueryUint32)
+}
+
+// QueryUint64Optional returns a query parameter with uint64 type or nil, if it is absent
+func QueryUint64Optional(r *http.Request, key string) (*uint64, error) {
+	return queryOptional(r, key, QueryUint64)
+}
+
+// QueryBoolOptional returns a query parameter with boolean type or nil, if it is absent
+func QueryBoolOptional(r *http.Request, key string) (*bool, error) {
+	return queryOptional(r, key, QueryBool)
+}
+
+// QueryFloat32Optional returns a query parameter with float32 type or nil, if it is absent
+func QueryFloat32Optional(r *http.Request, key string) (*float32, error) {
+	return queryOptional(r, key, QueryFloat32)
+}
+
+// QueryFloat64Optional returns a query parameter with float64 type or nil, if it is absent
+func QueryFloat64Optional(r *http.Request, key string) (*float64, error) {
+	return queryOptional(r, key, QueryFloat64)
+}
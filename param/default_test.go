@@ -0,0 +1,82 @@
+package param
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQueryIntDefault(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{name: "absent", query: "other=1", want: 50},
+		{name: "present", query: "limit=10", want: 10},
+		{name: "invalid", query: "limit=ten", want: 50, wantErr: true},
+		{name: "empty", query: "limit=", want: 50, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueryIntDefault(newQueryRequest(t, tt.query), "limit", 50)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryIntDefault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidParam) {
+				t.Errorf("QueryIntDefault() error = %v, want ErrInvalidParam", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryDefault_Types(t *testing.T) {
+	r := newQueryRequest(t, "s=&b=true&f=1.5&u=7")
+
+	if got, err := QueryStringDefault(r, "s", "x"); err != nil || got != "" {
+		t.Errorf("QueryStringDefault() = %q, %v, want empty string", got, err)
+	}
+	if got, err := QueryStringDefault(r, "none", "x"); err != nil || got != "x" {
+		t.Errorf("QueryStringDefault() = %q, %v, want %q", got, err, "x")
+	}
+	if got, err := QueryBoolDefault(r, "b", false); err != nil || !got {
+		t.Errorf("QueryBoolDefault() = %v, %v, want true", got, err)
+	}
+	if got, err := QueryFloat32Default(r, "f", 0); err != nil || got != 1.5 {
+		t.Errorf("QueryFloat32Default() = %v, %v, want 1.5", got, err)
+	}
+	if got, err := QueryUint16Default(r, "u", 3); err != nil || got != 7 {
+		t.Errorf("QueryUint16Default() = %v, %v, want 7", got, err)
+	}
+	if got, err := QueryInt64Default(r, "none", -1); err != nil || got != -1 {
+		t.Errorf("QueryInt64Default() = %v, %v, want -1", got, err)
+	}
+}
+
+func TestQueryOptional(t *testing.T) {
+	r := newQueryRequest(t, "active=false&id=abc&n=3")
+
+	active, err := QueryBoolOptional(r, "active")
+	if err != nil || active == nil || *active {
+		t.Errorf("QueryBoolOptional() = %v, %v, want false", active, err)
+	}
+	n, err := QueryUint8Optional(r, "n")
+	if err != nil || n == nil || *n != 3 {
+		t.Errorf("QueryUint8Optional() = %v, %v, want 3", n, err)
+	}
+	absent, err := QueryInt32Optional(r, "none")
+	if err != nil || absent != nil {
+		t.Errorf("QueryInt32Optional() = %v, %v, want nil", absent, err)
+	}
+	invalid, err := QueryInt64Optional(r, "id")
+	if !errors.Is(err, ErrInvalidParam) || invalid != nil {
+		t.Errorf("QueryInt64Optional() = %v, %v, want invalid param error", invalid, err)
+	}
+	s, err := QueryStringOptional(r, "id")
+	if err != nil || s == nil || *s != "abc" {
+		t.Errorf("QueryStringOptional() = %v, %v, want abc", s, err)
+	}
+}
@@ -0,0 +1,98 @@
+package param
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingParam is the cause of an Error for an absent parameter
+var ErrMissingParam = errors.New("missing")
+
+// Error describes an absent or invalid parameter. It matches ErrInvalidParam with errors.Is
+// and unwraps to the cause, ErrMissingParam or the parse error.
+type Error struct {
+	// Key name of the parameter
+	Key string
+	// Source of the parameter: path, query, form, header or cookie
+	Source string
+	// Value raw value, empty for absent parameters
+	Value string
+	// Type expected type, e.g. int64
+	Type string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	msg := e.Source + " parameter " + strconv.Quote(e.Key)
+	if errors.Is(e.Err, ErrMissingParam) {
+		return msg + " is missing"
+	}
+	msg += ": invalid value " + strconv.Quote(e.Value)
+	if e.Type != "" {
+		msg += ", expected " + e.Type
+	}
+	var numErr *strconv.NumError
+	if errors.As(e.Err, &numErr) {
+		return msg + ": " + numErr.Err.Error()
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports ErrInvalidParam
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalidParam
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects the errors of several parameters
+type MultiError []*Error
+
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports ErrInvalidParam
+func (m MultiError) Is(target error) bool {
+	return target == ErrInvalidParam
+}
+
+// Unwrap returns the single errors for errors.Is and errors.As, which follow it since
+// Go 1.20, the version declared by go.mod
+func (m MultiError) Unwrap() []error {
+	errs := make([]error, len(m))
+	for i, err := range m {
+		errs[i] = err
+	}
+	return errs
+}
+
+// newError returns nil, if err is nil, and an *Error otherwise
+func newError(source, key, value, typ string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Key: key, Source: source, Value: value, Type: typ, Err: err}
+}
+
+func missingError(source, key, typ string) error {
+	return &Error{Key: key, Source: source, Type: typ, Err: ErrMissingParam}
+}
+
+// pathError reports an invalid or, if value is empty, missing path parameter
+func pathError(key, value, typ string, err error) error {
+	if err != nil && value == "" {
+		return missingError(SourcePath, key, typ)
+	}
+	return newError(SourcePath, key, value, typ, err)
+}
@@ -0,0 +1,93 @@
+package param
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{
+			name: "missing",
+			err:  &Error{Key: "limit", Source: SourceQuery, Type: "int", Err: ErrMissingParam},
+			want: `query parameter "limit" is missing`,
+		},
+		{
+			name: "syntax",
+			err:  &Error{Key: "id", Source: SourcePath, Value: "abc", Type: "int64", Err: &strconv.NumError{Func: "ParseInt", Num: "abc", Err: strconv.ErrSyntax}},
+			want: `path parameter "id": invalid value "abc", expected int64: invalid syntax`,
+		},
+		{
+			name: "other cause",
+			err:  &Error{Key: "q", Source: SourceQuery, Value: "x", Err: errors.New("too short")},
+			want: `query parameter "q": invalid value "x": too short`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+			if !errors.Is(tt.err, ErrInvalidParam) {
+				t.Error("Error does not match ErrInvalidParam")
+			}
+			if !errors.Is(tt.err, tt.err.Err) {
+				t.Error("Error does not unwrap to its cause")
+			}
+		})
+	}
+}
+
+func TestError_Functions(t *testing.T) {
+	req, key := newParamRequest(t, "300")
+	_, err := Uint8(req, key)
+
+	var perr *Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("Uint8() error = %T, want *Error", err)
+	}
+	if perr.Key != key || perr.Source != SourcePath || perr.Value != "300" || perr.Type != "uint8" {
+		t.Errorf("unexpected error %+v", perr)
+	}
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Uint8() error = %v, want range error", err)
+	}
+
+	_, err = Int(req, "missing")
+	if !errors.Is(err, ErrMissingParam) || !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("Int() error = %v, want missing parameter", err)
+	}
+
+	_, err = QueryInt64(newQueryRequest(t, "a=1"), "b")
+	if !errors.As(err, &perr) || perr.Source != SourceQuery || perr.Type != "int64" || !errors.Is(err, ErrMissingParam) {
+		t.Errorf("QueryInt64() error = %v, want missing int64 query parameter", err)
+	}
+
+	_, err = QueryFloat64Array(newQueryRequest(t, "f=1&f=x"), "f")
+	if !errors.As(err, &perr) || perr.Value != "x" || perr.Type != "float64" {
+		t.Errorf("QueryFloat64Array() error = %v, want invalid value x", err)
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	missing := &Error{Key: "a", Source: SourceQuery, Err: ErrMissingParam}
+	invalid := &Error{Key: "b", Source: SourceQuery, Value: "x", Type: "bool", Err: strconv.ErrSyntax}
+	var err error = MultiError{missing, invalid}
+
+	want := `query parameter "a" is missing; query parameter "b": invalid value "x", expected bool: invalid syntax`
+	if got := err.Error(); got != want {
+		t.Errorf("got '%s', want '%s'", got, want)
+	}
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrMissingParam) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("MultiError does not match its causes")
+	}
+	var perr *Error
+	if !errors.As(err, &perr) || perr != missing {
+		t.Errorf("errors.As() = %v, want first error", perr)
+	}
+}
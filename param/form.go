@@ -0,0 +1,225 @@
+package param
+
+import (
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// FormOptions configures reading form bodies
+type FormOptions struct {
+	// MaxMemory of multipart forms, larger files are stored in temporary files, see http.Request.ParseMultipartForm
+	MaxMemory int64
+	// MaxBodySize of forms in bytes, reading stops with an error as soon as it is exceeded.
+	// 0 keeps the limits of net/http, which does not limit multipart bodies.
+	MaxBodySize int64
+}
+
+// DefaultFormOptions is used by the Form functions and Bind
+// nolint[gochecknoblobals]
+var DefaultFormOptions = FormOptions{MaxMemory: 32 << 20, MaxBodySize: 64 << 20}
+
+// parseForm parses url encoded and multipart bodies once
+func parseForm(r *http.Request) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	isMultipart := mediaType == "multipart/form-data"
+	if r.PostForm != nil && (!isMultipart || r.MultipartForm != nil) {
+		return nil
+	}
+
+	if DefaultFormOptions.MaxBodySize > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, DefaultFormOptions.MaxBodySize)
+	}
+	if isMultipart {
+		return r.ParseMultipartForm(DefaultFormOptions.MaxMemory)
+	}
+	return r.ParseForm()
+}
+
+// Form returns the form parameter key as T, see Path for the supported types
+func Form[T any](r *http.Request, key string) (T, error) {
+	return first(FormList[T](r, key, ListOptions{}))
+}
+
+// FormList returns the values of the list form parameter key as T, see QueryList.
+// Errors reading the body are returned as *Error.
+func FormList[T any](r *http.Request, key string, opts ...ListOptions) ([]T, error) {
+	if err := parseForm(r); err != nil {
+		return nil, &Error{Key: key, Source: SourceForm, Type: typeOf[T]().String(), Err: err}
+	}
+	return parseList[T](valuesLookup(r.PostForm), SourceForm, key, listOptions(opts))
+}
+
+// FormFile returns the uploaded file key of a multipart form. Files larger than maxSize
+// bytes are rejected, maxSize <= 0 disables the check. maxSize is checked after the
+// whole body has been parsed, use DefaultFormOptions.MaxBodySize to stop reading
+// oversized bodies.
+func FormFile(r *http.Request, key string, maxSize int64) (*multipart.FileHeader, error) {
+	files, err := FormFiles(r, key, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+// FormFiles returns all uploaded files key of a multipart form, see FormFile.
+func FormFiles(r *http.Request, key string, maxSize int64) ([]*multipart.FileHeader, error) {
+	if err := parseForm(r); err != nil {
+		return nil, &Error{Key: key, Source: SourceForm, Type: "file", Err: err}
+	}
+	if r.MultipartForm == nil || len(r.MultipartForm.File[key]) == 0 {
+		return nil, missingError(SourceForm, key, "file")
+	}
+
+	files := r.MultipartForm.File[key]
+	for _, fh := range files {
+		if maxSize > 0 && fh.Size > maxSize {
+			return nil, &Error{
+				Key: key, Source: SourceForm, Value: fh.Filename, Type: "file",
+				Err: &ConstraintError{Constraint: "maxsize", Limit: strconv.FormatInt(maxSize, 10)},
+			}
+		}
+	}
+	return files, nil
+}
+
+// FormStringArray returns a slice of form parameters with string type, see DefaultListOptions
+func FormStringArray(r *http.Request, key string) ([]string, error) {
+	return FormList[string](r, key)
+}
+
+// FormIntArray returns a slice of form parameters with int type, see DefaultListOptions
+func FormIntArray(r *http.Request, key string) ([]int, error) {
+	return FormList[int](r, key)
+}
+
+// FormInt8Array returns a slice of form parameters with int8 type, see DefaultListOptions
+func FormInt8Array(r *http.Request, key string) ([]int8, error) {
+	return FormList[int8](r, key)
+}
+
+// FormInt16Array returns a slice of form parameters with int16 type, see DefaultListOptions
+func FormInt16Array(r *http.Request, key string) ([]int16, error) {
+	return FormList[int16](r, key)
+}
+
+// FormInt32Array returns a slice of form parameters with int32 type, see DefaultListOptions
+func FormInt32Array(r *http.Request, key string) ([]int32, error) {
+	return FormList[int32](r, key)
+}
+
+// FormInt64Array returns a slice of form parameters with int64 type, see DefaultListOptions
+func FormInt64Array(r *http.Request, key string) ([]int64, error) {
+	return FormList[int64](r, key)
+}
+
+// FormUintArray returns a slice of form parameters with uint type, see DefaultListOptions
+func FormUintArray(r *http.Request, key string) ([]uint, error) {
+	return FormList[uint](r, key)
+}
+
+// FormUint8Array returns a slice of form parameters with uint8 type, see DefaultListOptions
+func FormUint8Array(r *http.Request, key string) ([]uint8, error) {
+	return FormList[uint8](r, key)
+}
+
+// FormUint16Array returns a slice of form parameters with uint16 type, see DefaultListOptions
+func FormUint16Array(r *http.Request, key string) ([]uint16, error) {
+	return FormList[uint16](r, key)
+}
+
+// FormUint32Array returns a slice of form parameters with uint32 type, see DefaultListOptions
+func FormUint32Array(r *http.Request, key string) ([]uint32, error) {
+	return FormList[uint32](r, key)
+}
+
+// FormUint64Array returns a slice of form parameters with uint64 type, see DefaultListOptions
+func FormUint64Array(r *http.Request, key string) ([]uint64, error) {
+	return FormList[uint64](r, key)
+}
+
+// FormBoolArray returns a slice of form parameters with boolean type, see DefaultListOptions
+func FormBoolArray(r *http.Request, key string) ([]bool, error) {
+	return FormList[bool](r, key)
+}
+
+// FormFloat32Array returns a slice of form parameters with float32 type, see DefaultListOptions
+func FormFloat32Array(r *http.Request, key string) ([]float32, error) {
+	return FormList[float32](r, key)
+}
+
+// FormFloat64Array returns a slice of form parameters with float64 type, see DefaultListOptions
+func FormFloat64Array(r *http.Request, key string) ([]float64, error) {
+	return FormList[float64](r, key)
+}
+
+// FormString returns a form parameter with string type
+func FormString(r *http.Request, key string) (string, error) {
+	return Form[string](r, key)
+}
+
+// FormInt returns a form parameter with int type
+func FormInt(r *http.Request, key string) (int, error) {
+	return Form[int](r, key)
+}
+
+// FormInt8 returns a form parameter with int8 type
+func FormInt8(r *http.Request, key string) (int8, error) {
+	return Form[int8](r, key)
+}
+
+// FormInt16 returns a form parameter with int16 type
+func FormInt16(r *http.Request, key string) (int16, error) {
+	return Form[int16](r, key)
+}
+
+// FormInt32 returns a form parameter with int32 type
+func FormInt32(r *http.Request, key string) (int32, error) {
+	return Form[int32](r, key)
+}
+
+// FormInt64 returns a form parameter with int64 type
+func FormInt64(r *http.Request, key string) (int64, error) {
+	return Form[int64](r, key)
+}
+
+// FormUint returns a form parameter with uint type
+func FormUint(r *http.Request, key string) (uint, error) {
+	return Form[uint](r, key)
+}
+
+// FormUint8 returns a form parameter with uint8 type
+func FormUint8(r *http.Request, key string) (uint8, error) {
+	return Form[uint8](r, key)
+}
+
+// FormUint16 returns a form parameter with uint16 type
+func FormUint16(r *http.Request, key string) (uint16, error) {
+	return Form[uint16](r, key)
+}
+
+// FormUint32 returns a form parameter with uint32 type
+func FormUint32(r *http.Request, key string) (uint32, error) {
+	return Form[uint32](r, key)
+}
+
+// FormUint64 returns a form parameter with uint64 type
+func FormUint64(r *http.Request, key string) (uint64, error) {
+	return Form[uint64](r, key)
+}
+
+// FormBool returns a form parameter with boolean type
+func FormBool(r *http.Request, key string) (bool, error) {
+	return Form[bool](r, key)
+}
+
+// FormFloat32 returns a form parameter with float32 type
+func FormFloat32(r *http.Request, key string) (float32, error) {
+	return Form[float32](r, key)
+}
+
+// FormFloat64 returns a form parameter with float64 type
+func FormFloat64(r *http.Request, key string) (float64, error) {
+	return Form[float64](r, key)
+}
@@ -0,0 +1,72 @@
+package param
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// nolint[gochecknoblobals]
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Path returns the path parameter key as T. Supported are string, bool, the int, uint
+// and float types, types derived from them like `type UserID int64` and all types whose
+// pointer implements encoding.TextUnmarshaler, e.g. UUIDs, enums or time.Time.
+// encoding.TextUnmarshaler takes precedence over the underlying type.
+//
+// As with the typed functions uint is parsed with 32 bit. Other types are returned as error
+// which does not match ErrInvalidParam.
+func Path[T any](r *http.Request, key string) (T, error) {
+	var zero T
+	parse, typ, err := parser[T](SourcePath)
+	if err != nil {
+		return zero, err
+	}
+
+	s := pathValue(r, key)
+	if s == "" {
+		return zero, missingError(SourcePath, key, typ)
+	}
+	v, err := parse(s)
+	if err != nil {
+		return zero, newError(SourcePath, key, s, typ, err)
+	}
+	return v, nil
+}
+
+// Query returns the query parameter key as T, see Path for the supported types
+func Query[T any](r *http.Request, key string) (T, error) {
+	return first(QueryList[T](r, key, ListOptions{}))
+}
+
+// QueryList returns the values of the list query parameter key as T, encoded in the
+// styles of opts or DefaultListOptions if omitted. See Path for the supported types.
+func QueryList[T any](r *http.Request, key string, opts ...ListOptions) ([]T, error) {
+	return parseList[T](valuesLookup(r.URL.Query()), SourceQuery, key, listOptions(opts))
+}
+
+func listOptions(opts []ListOptions) ListOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return DefaultListOptions
+}
+
+// parser returns the parse function and type name of T for source
+func parser[T any](source string) (func(string) (T, error), string, error) {
+	t := typeOf[T]()
+	if !isScalar(t.Kind()) && !isText(t) {
+		return nil, t.String(), fmt.Errorf("param: unsupported type %s", t)
+	}
+
+	return func(s string) (T, error) {
+		var v T
+		err := setScalar(reflect.ValueOf(&v).Elem(), s, source)
+		return v, err
+	}, t.String(), nil
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,156 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type userID int64
+
+type status uint8
+
+const (
+	statusActive status = iota + 1
+	statusLocked
+)
+
+func (s *status) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "active":
+		*s = statusActive
+	case "locked":
+		*s = statusLocked
+	default:
+		return fmt.Errorf("unknown status %q", text)
+	}
+	return nil
+}
+
+// tags is a TextUnmarshaler which is not comparable
+type tags struct {
+	names []string
+}
+
+func (t *tags) UnmarshalText(text []byte) error {
+	t.names = []string{string(text)}
+	return nil
+}
+
+func TestPath(t *testing.T) {
+	r := WithPathValues(httptest.NewRequest("GET", "/", nil), map[string]string{
+		"id": "42", "status": "locked", "at": "2020-03-04T05:06:07Z", "bad": "unknown",
+	})
+
+	if got, err := Path[userID](r, "id"); err != nil || got != 42 {
+		t.Errorf("Path[userID]() = %v, %v, want 42", got, err)
+	}
+	if got, err := Path[status](r, "status"); err != nil || got != statusLocked {
+		t.Errorf("Path[status]() = %v, %v, want %v", got, err, statusLocked)
+	}
+	if got, err := Path[time.Time](r, "at"); err != nil || !got.Equal(time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)) {
+		t.Errorf("Path[time.Time]() = %v, %v", got, err)
+	}
+
+	_, err := Path[status](r, "bad")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Type != "param.status" || perr.Value != "unknown" {
+		t.Errorf("Path[status]() error = %#v", err)
+	}
+	if _, err := Path[userID](r, "missing"); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("Path[userID]() error = %v, want ErrMissingParam", err)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	r := newQueryRequest(t, "id=7&status=active&n=1e%2B3&max=4294967296")
+
+	if got, err := Query[userID](r, "id"); err != nil || got != 7 {
+		t.Errorf("Query[userID]() = %v, %v, want 7", got, err)
+	}
+	if got, err := Query[status](r, "status"); err != nil || got != statusActive {
+		t.Errorf("Query[status]() = %v, %v, want %v", got, err, statusActive)
+	}
+	if got, err := Query[float64](r, "n"); err != nil || got != 1000 {
+		t.Errorf("Query[float64]() = %v, %v, want 1000", got, err)
+	}
+	if _, err := Query[uint](r, "max"); !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("Query[uint]() error = %v, want 32 bit range error", err)
+	}
+	if got, err := Query[uint64](r, "max"); err != nil || got != 1<<32 {
+		t.Errorf("Query[uint64]() = %v, %v", got, err)
+	}
+}
+
+func TestQueryUnsupportedType(t *testing.T) {
+	r := newQueryRequest(t, "id=1")
+
+	_, err := Query[[]int](r, "id")
+	if err == nil || errors.Is(err, ErrInvalidParam) {
+		t.Errorf("Query[[]int]() error = %v, want unsupported type", err)
+	}
+}
+
+func TestQueryListGeneric(t *testing.T) {
+	r := newQueryRequest(t, "status=active,locked,active&tag=a,a")
+	opts := ListOptions{Style: ListCSV, Dedupe: true}
+
+	got, err := QueryList[status](r, "status", opts)
+	if want := []status{statusActive, statusLocked}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("QueryList[status]() = %v, %v, want %v", got, err, want)
+	}
+
+	tagList, err := QueryList[tags](r, "tag", opts)
+	if err != nil || len(tagList) != 1 {
+		t.Errorf("QueryList[tags]() = %v, %v, want one item", tagList, err)
+	}
+
+	ids, err := QueryList[userID](r, "status")
+	if ids != nil || !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("QueryList[userID]() = %v, %v, want error", ids, err)
+	}
+}
+
+func TestSourceGeneric(t *testing.T) {
+	r := newFormRequest(t, "status=locked")
+	r.Header.Set("X-User", "3")
+	r.AddCookie(&http.Cookie{Name: "status", Value: "active"})
+
+	if got, err := Form[status](r, "status"); err != nil || got != statusLocked {
+		t.Errorf("Form[status]() = %v, %v", got, err)
+	}
+	if got, err := HeaderList[userID](r, "X-User"); err != nil || !reflect.DeepEqual(got, []userID{3}) {
+		t.Errorf("HeaderList[userID]() = %v, %v", got, err)
+	}
+	if got, err := Cookie[status](r, "status"); err != nil || got != statusActive {
+		t.Errorf("Cookie[status]() = %v, %v", got, err)
+	}
+}
+
+func TestBindTextUnmarshaler(t *testing.T) {
+	var dst struct {
+		ID     userID    `path:"id"`
+		Status *status   `query:"status"`
+		Since  time.Time `query:"since"`
+		All    []status  `query:"all"`
+	}
+	r := newBindRequest(t, map[string]string{"id": "5"}, "status=locked&since=2020-01-02T00:00:00Z&all=active&all=locked")
+
+	if err := Bind(r, &dst); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if dst.ID != 5 || dst.Status == nil || *dst.Status != statusLocked || dst.Since.Year() != 2020 ||
+		!reflect.DeepEqual(dst.All, []status{statusActive, statusLocked}) {
+		t.Errorf("Bind() = %+v", dst)
+	}
+
+	err := Bind(newBindRequest(t, map[string]string{"id": "5"}, "since=yesterday"), &dst)
+	var merr MultiError
+	if !errors.As(err, &merr) || len(merr) != 1 || merr[0].Type != "time.Time" {
+		t.Errorf("Bind() error = %v, want invalid time.Time", err)
+	}
+}
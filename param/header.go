@@ -0,0 +1,161 @@
+package param
+
+import "net/http"
+
+// Header returns the request header key as T, see Path for the supported types
+func Header[T any](r *http.Request, key string) (T, error) {
+	return first(HeaderList[T](r, key, ListOptions{}))
+}
+
+// HeaderList returns the values of the list header key as T, see QueryList.
+// Repeated headers are read like repeated query keys.
+func HeaderList[T any](r *http.Request, key string, opts ...ListOptions) ([]T, error) {
+	return parseList[T](headerLookup(r), SourceHeader, key, listOptions(opts))
+}
+
+func headerLookup(r *http.Request) lookup {
+	return func(key string) ([]string, bool) {
+		values := r.Header.Values(key)
+		return values, len(values) > 0
+	}
+}
+
+// HeaderStringArray returns a slice of request headers with string type, see DefaultListOptions
+func HeaderStringArray(r *http.Request, key string) ([]string, error) {
+	return HeaderList[string](r, key)
+}
+
+// HeaderIntArray returns a slice of request headers with int type, see DefaultListOptions
+func HeaderIntArray(r *http.Request, key string) ([]int, error) {
+	return HeaderList[int](r, key)
+}
+
+// HeaderInt8Array returns a slice of request headers with int8 type, see DefaultListOptions
+func HeaderInt8Array(r *http.Request, key string) ([]int8, error) {
+	return HeaderList[int8](r, key)
+}
+
+// HeaderInt16Array returns a slice of request headers with int16 type, see DefaultListOptions
+func HeaderInt16Array(r *http.Request, key string) ([]int16, error) {
+	return HeaderList[int16](r, key)
+}
+
+// HeaderInt32Array returns a slice of request headers with int32 type, see DefaultListOptions
+func HeaderInt32Array(r *http.Request, key string) ([]int32, error) {
+	return HeaderList[int32](r, key)
+}
+
+// HeaderInt64Array returns a slice of request headers with int64 type, see DefaultListOptions
+func HeaderInt64Array(r *http.Request, key string) ([]int64, error) {
+	return HeaderList[int64](r, key)
+}
+
+// HeaderUintArray returns a slice of request headers with uint type, see DefaultListOptions
+func HeaderUintArray(r *http.Request, key string) ([]uint, error) {
+	return HeaderList[uint](r, key)
+}
+
+// HeaderUint8Array returns a slice of request headers with uint8 type, see DefaultListOptions
+func HeaderUint8Array(r *http.Request, key string) ([]uint8, error) {
+	return HeaderList[uint8](r, key)
+}
+
+// HeaderUint16Array returns a slice of request headers with uint16 type, see DefaultListOptions
+func HeaderUint16Array(r *http.Request, key string) ([]uint16, error) {
+	return HeaderList[uint16](r, key)
+}
+
+// HeaderUint32Array returns a slice of request headers with uint32 type, see DefaultListOptions
+func HeaderUint32Array(r *http.Request, key string) ([]uint32, error) {
+	return HeaderList[uint32](r, key)
+}
+
+// HeaderUint64Array returns a slice of request headers with uint64 type, see DefaultListOptions
+func HeaderUint64Array(r *http.Request, key string) ([]uint64, error) {
+	return HeaderList[uint64](r, key)
+}
+
+// HeaderBoolArray returns a slice of request headers with boolean type, see DefaultListOptions
+func HeaderBoolArray(r *http.Request, key string) ([]bool, error) {
+	return HeaderList[bool](r, key)
+}
+
+// HeaderFloat32Array returns a slice of request headers with float32 type, see DefaultListOptions
+func HeaderFloat32Array(r *http.Request, key string) ([]float32, error) {
+	return HeaderList[float32](r, key)
+}
+
+// HeaderFloat64Array returns a slice of request headers with float64 type, see DefaultListOptions
+func HeaderFloat64Array(r *http.Request, key string) ([]float64, error) {
+	return HeaderList[float64](r, key)
+}
+
+// HeaderString returns a request header with string type
+func HeaderString(r *http.Request, key string) (string, error) {
+	return Header[string](r, key)
+}
+
+// HeaderInt returns a request header with int type
+func HeaderInt(r *http.Request, key string) (int, error) {
+	return Header[int](r, key)
+}
+
+// HeaderInt8 returns a request header with int8 type
+func HeaderInt8(r *http.Request, key string) (int8, error) {
+	return Header[int8](r, key)
+}
+
+// HeaderInt16 returns a request header with int16 type
+func HeaderInt16(r *http.Request, key string) (int16, error) {
+	return Header[int16](r, key)
+}
+
+// HeaderInt32 returns a request header with int32 type
+func HeaderInt32(r *http.Request, key string) (int32, error) {
+	return Header[int32](r, key)
+}
+
+// HeaderInt64 returns a request header with int64 type
+func HeaderInt64(r *http.Request, key string) (int64, error) {
+	return Header[int64](r, key)
+}
+
+// HeaderUint returns a request header with uint type
+func HeaderUint(r *http.Request, key string) (uint, error) {
+	return Header[uint](r, key)
+}
+
+// HeaderUint8 returns a request header with uint8 type
+func HeaderUint8(r *http.Request, key string) (uint8, error) {
+	return Header[uint8](r, key)
+}
+
+// HeaderUint16 returns a request header with uint16 type
+func HeaderUint16(r *http.Request, key string) (uint16, error) {
+	return Header[uint16](r, key)
+}
+
+// HeaderUint32 returns a request header with uint32 type
+func HeaderUint32(r *http.Request, key string) (uint32, error) {
+	return Header[uint32](r, key)
+}
+
+// HeaderUint64 returns a request header with uint64 type
+func HeaderUint64(r *http.Request, key string) (uint64, error) {
+	return Header[uint64](r, key)
+}
+
+// HeaderBool returns a request header with boolean type
+func HeaderBool(r *http.Request, key string) (bool, error) {
+	return Header[bool](r, key)
+}
+
+// HeaderFloat32 returns a request header with float32 type
+func HeaderFloat32(r *http.Request, key string) (float32, error) {
+	return Header[float32](r, key)
+}
+
+// HeaderFloat64 returns a request header with float64 type
+func HeaderFloat64(r *http.Request, key string) (float64, error) {
+	return Header[float64](r, key)
+}
@@ -0,0 +1,127 @@
+package param
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListStyle selects how list parameters are encoded in the query. Styles can be combined.
+type ListStyle uint8
+
+const (
+	// ListRepeated repeated keys ?id=1&id=2, always accepted
+	ListRepeated ListStyle = 0
+	// ListCSV comma separated values ?id=1,2
+	ListCSV ListStyle = 1 << iota
+	// ListBracket repeated keys with brackets ?id[]=1&id[]=2
+	ListBracket
+	// ListPipe pipe separated values ?id=1|2
+	ListPipe
+)
+
+// ListOptions configures list parameters
+type ListOptions struct {
+	Style ListStyle
+	// Dedupe removes duplicate values and keeps the first occurrence
+	Dedupe bool
+	// MaxItems before deduplication, 0 means unlimited
+	MaxItems int
+}
+
+// DefaultListOptions is used by the Query*Array functions and Bind
+// nolint[gochecknoblobals]
+var DefaultListOptions = ListOptions{Style: ListRepeated}
+
+// lookup returns the values of a parameter and whether it is present
+type lookup func(key string) ([]string, bool)
+
+func valuesLookup(values url.Values) lookup {
+	return func(key string) ([]string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+// splitList returns the items of key and whether the key is present
+func splitList(get lookup, key string, opts ListOptions) ([]string, bool) {
+	values, ok := get(key)
+	if opts.Style&ListBracket != 0 {
+		if bracket, found := get(key + "[]"); found {
+			values = append(values[:len(values):len(values)], bracket...)
+			ok = true
+		}
+	}
+	if !ok || opts.Style&(ListCSV|ListPipe) == 0 {
+		return values, ok
+	}
+
+	seps := ""
+	if opts.Style&ListCSV != 0 {
+		seps += ","
+	}
+	if opts.Style&ListPipe != 0 {
+		seps += "|"
+	}
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		for _, item := range strings.FieldsFunc(v, func(r rune) bool { return strings.ContainsRune(seps, r) }) {
+			items = append(items, item)
+		}
+	}
+	return items, true
+}
+
+func tooManyItems(source, key, typ string, n int) error {
+	return &Error{Key: key, Source: source, Type: "[]" + typ, Err: &ConstraintError{Constraint: "maxitems", Limit: strconv.Itoa(n)}}
+}
+
+// parseList parses the list parameter key of source as T, see parser
+func parseList[T any](get lookup, source, key string, opts ListOptions) ([]T, error) {
+	parse, typ, err := parser[T](source)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := splitList(get, key, opts)
+	if !ok {
+		return nil, missingError(source, key, typ)
+	}
+	if opts.MaxItems > 0 && len(items) > opts.MaxItems {
+		return nil, tooManyItems(source, key, typ, opts.MaxItems)
+	}
+
+	out := make([]T, 0, len(items))
+	var seen map[interface{}]struct{}
+	if opts.Dedupe {
+		seen = make(map[interface{}]struct{}, len(items))
+	}
+	// values of types which are not comparable are deduplicated by their raw item
+	comparable := typeOf[T]().Comparable()
+	for _, item := range items {
+		v, err := parse(item)
+		if err != nil {
+			return nil, newError(source, key, item, typ, err)
+		}
+		if seen != nil {
+			var k interface{} = item
+			if comparable {
+				k = v
+			}
+			if _, dup := seen[k]; dup {
+				continue
+			}
+			seen[k] = struct{}{}
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// first returns the first value of a list parsed without list options
+func first[T any](values []T, err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return values[0], nil
+}
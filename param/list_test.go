@@ -0,0 +1,100 @@
+package param
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func setDefaultListOptions(t *testing.T, opts ListOptions) {
+	t.Helper()
+
+	old := DefaultListOptions
+	DefaultListOptions = opts
+	t.Cleanup(func() { DefaultListOptions = old })
+}
+
+func TestQueryList(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		opts    ListOptions
+		want    []string
+		wantErr bool
+	}{
+		{name: "repeated", query: "id=1&id=2", want: []string{"1", "2"}},
+		{name: "repeated ignores csv", query: "id=1,2", want: []string{"1,2"}},
+		{name: "csv", query: "id=1,2,3", opts: ListOptions{Style: ListCSV}, want: []string{"1", "2", "3"}},
+		{name: "csv and repeated", query: "id=1,2&id=3", opts: ListOptions{Style: ListCSV}, want: []string{"1", "2", "3"}},
+		{name: "csv skips empty", query: "id=1,,2,", opts: ListOptions{Style: ListCSV}, want: []string{"1", "2"}},
+		{name: "csv empty", query: "id=", opts: ListOptions{Style: ListCSV}, want: []string{}},
+		{name: "bracket", query: "id%5B%5D=1&id[]=2", opts: ListOptions{Style: ListBracket}, want: []string{"1", "2"}},
+		{name: "bracket and plain", query: "id=0&id[]=1", opts: ListOptions{Style: ListBracket}, want: []string{"0", "1"}},
+		{name: "bracket disabled", query: "id[]=1", wantErr: true},
+		{name: "pipe", query: "id=1|2", opts: ListOptions{Style: ListPipe}, want: []string{"1", "2"}},
+		{name: "all styles", query: "id=1|2,3&id[]=4", opts: ListOptions{Style: ListCSV | ListPipe | ListBracket}, want: []string{"1", "2", "3", "4"}},
+		{name: "dedupe", query: "id=b,a,b,a", opts: ListOptions{Style: ListCSV, Dedupe: true}, want: []string{"b", "a"}},
+		{name: "max items", query: "id=1,2,3", opts: ListOptions{Style: ListCSV, MaxItems: 2}, wantErr: true},
+		{name: "max items before dedupe", query: "id=1,1,1", opts: ListOptions{Style: ListCSV, Dedupe: true, MaxItems: 2}, wantErr: true},
+		{name: "missing", query: "other=1", opts: ListOptions{Style: ListCSV}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueryList[string](newQueryRequest(t, tt.query), "id", tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryArray_DefaultListOptions(t *testing.T) {
+	setDefaultListOptions(t, ListOptions{Style: ListCSV | ListBracket, Dedupe: true, MaxItems: 5})
+
+	got, err := QueryIntArray(newQueryRequest(t, "id=3,1,3&id[]=2"), "id")
+	if err != nil || !reflect.DeepEqual(got, []int{3, 1, 2}) {
+		t.Errorf("QueryIntArray() = %v, %v, want [3 1 2]", got, err)
+	}
+
+	// deduplication compares parsed values
+	floats, err := QueryFloat64Array(newQueryRequest(t, "f=1.0,1,2e0"), "f")
+	if err != nil || !reflect.DeepEqual(floats, []float64{1, 2}) {
+		t.Errorf("QueryFloat64Array() = %v, %v, want [1 2]", floats, err)
+	}
+
+	_, err = QueryIntArray(newQueryRequest(t, "id=1,2,3,4,5,6"), "id")
+	var cerr *ConstraintError
+	if !errors.As(err, &cerr) || cerr.Constraint != "maxitems" || !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("QueryIntArray() error = %v, want maxitems", err)
+	}
+
+	// scalars are never split
+	s, err := QueryString(newQueryRequest(t, "q=a,b"), "q")
+	if err != nil || s != "a,b" {
+		t.Errorf("QueryString() = %q, %v, want %q", s, err, "a,b")
+	}
+}
+
+func TestBind_ListStyles(t *testing.T) {
+	setDefaultListOptions(t, ListOptions{Style: ListCSV | ListBracket, Dedupe: true, MaxItems: 3})
+
+	var dst struct {
+		IDs  []int    `query:"id"`
+		Tags []string `query:"tag"`
+	}
+	if err := Bind(newBindRequest(t, nil, "id=1,2,2&tag[]=a&tag[]=b"), &dst); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.IDs, []int{1, 2}) || !reflect.DeepEqual(dst.Tags, []string{"a", "b"}) {
+		t.Errorf("got %+v", dst)
+	}
+
+	err := Bind(newBindRequest(t, nil, "id=1,2,3,4"), &dst)
+	var cerr *ConstraintError
+	if !errors.As(err, &cerr) || cerr.Constraint != "maxitems" {
+		t.Errorf("Bind() error = %v, want maxitems", err)
+	}
+}
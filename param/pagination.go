@@ -0,0 +1,347 @@
+package param
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+var (
+	errCursor        = errors.New("param: invalid cursor")
+	errCursorSecret  = errors.New("param: PageOptions.Secret is needed to sign cursors")
+	errKeysetColumns = errors.New("param: number of keyset columns does not match the cursor")
+)
+
+// PageOptions configures Pagination. Empty keys and sizes are taken from DefaultPageOptions.
+type PageOptions struct {
+	PageKey   string
+	SizeKey   string
+	OffsetKey string
+	LimitKey  string
+	CursorKey string
+	// DefaultSize of a page without size or limit parameter
+	DefaultSize int
+	// MaxSize of a page, larger sizes are capped
+	MaxSize int
+	// Secret signs cursors with HMAC-SHA256, cursors are rejected without it
+	Secret []byte
+}
+
+// DefaultPageOptions reads ?page=2&per_page=20, ?offset=20&limit=20 or ?cursor=...&limit=20
+// nolint[gochecknoblobals]
+var DefaultPageOptions = PageOptions{
+	PageKey:     "page",
+	SizeKey:     "per_page",
+	OffsetKey:   "offset",
+	LimitKey:    "limit",
+	CursorKey:   "cursor",
+	DefaultSize: 20,
+	MaxSize:     100,
+}
+
+func (o PageOptions) withDefaults() PageOptions {
+	def := DefaultPageOptions
+	o.PageKey = orDefault(o.PageKey, def.PageKey)
+	o.SizeKey = orDefault(o.SizeKey, def.SizeKey)
+	o.OffsetKey = orDefault(o.OffsetKey, def.OffsetKey)
+	o.LimitKey = orDefault(o.LimitKey, def.LimitKey)
+	o.CursorKey = orDefault(o.CursorKey, def.CursorKey)
+	if o.DefaultSize <= 0 {
+		o.DefaultSize = def.DefaultSize
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = def.MaxSize
+	}
+	return o
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// Cursor is the position of a keyset page, see Page.After and Page.Before
+type Cursor struct {
+	// Values of the order columns of the row next to the page
+	Values []string `json:"v"`
+	// Backward pages to the rows before Values
+	Backward bool `json:"b,omitempty"`
+}
+
+// Page of a list. Either Number and Offset, Offset alone or Cursor are set.
+type Page struct {
+	// Number of the page starting at 1, 0 for offset and cursor pagination
+	Number int
+	Offset int
+	Limit  int
+	Cursor *Cursor
+}
+
+// Pagination reads the page of r. A cursor takes precedence over offset and limit,
+// which take precedence over page and size. Sizes above opts.MaxSize are capped,
+// invalid parameters are returned as *Error.
+func Pagination(r *http.Request, opts PageOptions) (Page, error) {
+	opts = opts.withDefaults()
+	query := r.URL.Query()
+	get := valuesLookup(query)
+
+	sizeKey := opts.SizeKey
+	if hasValue(query, opts.LimitKey) {
+		sizeKey = opts.LimitKey
+	}
+	size, err := pageInt(get, sizeKey, opts.DefaultSize, 1)
+	if err != nil {
+		return Page{}, err
+	}
+	if size > opts.MaxSize {
+		size = opts.MaxSize
+	}
+
+	switch {
+	case hasValue(query, opts.CursorKey):
+		s := query.Get(opts.CursorKey)
+		c, err := opts.decodeCursor(s)
+		if err != nil {
+			return Page{}, &Error{Key: opts.CursorKey, Source: SourceQuery, Value: s, Type: "cursor", Err: err}
+		}
+		return Page{Limit: size, Cursor: c}, nil
+	case hasValue(query, opts.OffsetKey) || hasValue(query, opts.LimitKey):
+		offset, err := pageInt(get, opts.OffsetKey, 0, 0)
+		if err != nil {
+			return Page{}, err
+		}
+		return Page{Offset: offset, Limit: size}, nil
+	}
+
+	number, err := pageInt(get, opts.PageKey, 1, 1)
+	if err != nil {
+		return Page{}, err
+	}
+	// the offset (number-1)*size must not overflow
+	if max := math.MaxInt/size + 1; number > max {
+		return Page{}, &Error{
+			Key: opts.PageKey, Source: SourceQuery, Value: strconv.Itoa(number), Type: "int",
+			Err: &ConstraintError{Constraint: "max", Limit: strconv.Itoa(max)},
+		}
+	}
+	return Page{Number: number, Offset: (number - 1) * size, Limit: size}, nil
+}
+
+func hasValue(query url.Values, key string) bool {
+	return query.Get(key) != ""
+}
+
+// pageInt reads the int parameter key or def, if it is absent or empty
+func pageInt(get lookup, key string, def, min int) (int, error) {
+	if values, _ := get(key); len(values) == 0 || values[0] == "" {
+		return def, nil
+	}
+	n, err := first(parseList[int](get, SourceQuery, key, ListOptions{}))
+	if err != nil {
+		return 0, err
+	}
+	return n, Validate(SourceQuery, key, n, Min(min))
+}
+
+// Next returns the following page of offset pagination
+func (p Page) Next() Page {
+	next := Page{Offset: p.Offset + p.Limit, Limit: p.Limit}
+	if p.Number > 0 {
+		next.Number = p.Number + 1
+	}
+	return next
+}
+
+// Prev returns the previous page of offset pagination and false on the first page
+func (p Page) Prev() (Page, bool) {
+	if p.Offset == 0 {
+		return Page{}, false
+	}
+	prev := Page{Offset: p.Offset - p.Limit, Limit: p.Limit}
+	if prev.Offset < 0 {
+		prev.Offset = 0
+	}
+	if p.Number > 1 {
+		prev.Number = p.Number - 1
+	}
+	return prev, true
+}
+
+// After returns the keyset page following the row with the order column values
+func (p Page) After(values ...string) Page {
+	return Page{Limit: p.Limit, Cursor: &Cursor{Values: values}}
+}
+
+// Before returns the keyset page preceding the row with the order column values
+func (p Page) Before(values ...string) Page {
+	return Page{Limit: p.Limit, Cursor: &Cursor{Values: values, Backward: true}}
+}
+
+// Backward reports whether the rows of a keyset page have to be read in reverse order.
+// The query orders them reversed, so the result has to be reversed by the caller.
+func (p Page) Backward() bool {
+	return p.Cursor != nil && p.Cursor.Backward
+}
+
+// AppendLimit appends `LIMIT ? OFFSET ?` to sql, for keyset pages `LIMIT ?`,
+// and returns args with the values of the placeholders appended.
+func (p Page) AppendLimit(sql *sdb.SQLStatement, args []interface{}) []interface{} {
+	if p.Cursor != nil {
+		sql.Append("LIMIT ?")
+		return append(args, p.Limit)
+	}
+	sql.Append("LIMIT ? OFFSET ?")
+	return append(args, p.Limit, p.Offset)
+}
+
+// AppendKeyset appends the condition `(a,b) > (?,?)` of a keyset page in ascending order of
+// columns to sql, prefixed by prefix like WHERE or AND, and returns args with the values of
+// the cursor appended. Backward pages compare with <, without cursor nothing is appended.
+// If the number of columns does not match the values of the cursor, nothing is appended
+// and an error is returned.
+func (p Page) AppendKeyset(sql *sdb.SQLStatement, args []interface{}, prefix string, columns ...string) ([]interface{}, error) {
+	return p.appendKeyset(sql, args, prefix, ">", "<", columns)
+}
+
+// AppendKeysetDesc is AppendKeyset for columns in descending order
+func (p Page) AppendKeysetDesc(sql *sdb.SQLStatement, args []interface{}, prefix string, columns ...string) ([]interface{}, error) {
+	return p.appendKeyset(sql, args, prefix, "<", ">", columns)
+}
+
+func (p Page) appendKeyset(sql *sdb.SQLStatement, args []interface{}, prefix, forward, backward string, columns []string) ([]interface{}, error) {
+	if p.Cursor == nil {
+		return args, nil
+	}
+	if len(columns) != len(p.Cursor.Values) {
+		return args, errKeysetColumns
+	}
+
+	op := forward
+	if p.Cursor.Backward {
+		op = backward
+	}
+	if prefix != "" {
+		sql.Append(prefix)
+	}
+	sql.AppendFields("(", "", ",", ") "+op+" ", columns)
+	sql.AppendFiller("(", ",", ") ", "?", len(p.Cursor.Values))
+	for _, v := range p.Cursor.Values {
+		args = append(args, v)
+	}
+	return args, nil
+}
+
+// EncodeCursor returns the opaque, signed representation of c
+func (o PageOptions) EncodeCursor(c Cursor) (string, error) {
+	if len(o.Secret) == 0 {
+		return "", errCursorSecret
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(o.sign(payload)), nil
+}
+
+func (o PageOptions) decodeCursor(s string) (*Cursor, error) {
+	if len(o.Secret) == 0 {
+		return nil, errCursorSecret
+	}
+	enc := base64.RawURLEncoding
+	data, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, errCursor
+	}
+	payload, err := enc.DecodeString(data)
+	if err != nil {
+		return nil, errCursor
+	}
+	mac, err := enc.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, o.sign(payload)) {
+		return nil, errCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil || len(c.Values) == 0 {
+		return nil, errCursor
+	}
+	return &c, nil
+}
+
+func (o PageOptions) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, o.Secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// URL returns the url of r with the pagination parameters of p. Page numbers are
+// written with page and size, offsets with offset and limit and cursors with cursor and limit.
+func (o PageOptions) URL(r *http.Request, p Page) (string, error) {
+	o = o.withDefaults()
+	query := r.URL.Query()
+	for _, key := range []string{o.PageKey, o.SizeKey, o.OffsetKey, o.LimitKey, o.CursorKey} {
+		query.Del(key)
+	}
+
+	switch {
+	case p.Cursor != nil:
+		s, err := o.EncodeCursor(*p.Cursor)
+		if err != nil {
+			return "", err
+		}
+		query.Set(o.CursorKey, s)
+		query.Set(o.LimitKey, strconv.Itoa(p.Limit))
+	case p.Number > 0:
+		query.Set(o.PageKey, strconv.Itoa(p.Number))
+		query.Set(o.SizeKey, strconv.Itoa(p.Limit))
+	default:
+		query.Set(o.OffsetKey, strconv.Itoa(p.Offset))
+		query.Set(o.LimitKey, strconv.Itoa(p.Limit))
+	}
+
+	u := *r.URL
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Link returns the value of a Link header (RFC 8288) with the urls of the next and prev
+// pages, nil pages are skipped.
+func (o PageOptions) Link(r *http.Request, next, prev *Page) (string, error) {
+	var links []string
+	for _, l := range []struct {
+		rel  string
+		page *Page
+	}{{"next", next}, {"prev", prev}} {
+		if l.page == nil {
+			continue
+		}
+		u, err := o.URL(r, *l.page)
+		if err != nil {
+			return "", err
+		}
+		links = append(links, "<"+u+`>; rel="`+l.rel+`"`)
+	}
+	return strings.Join(links, ", "), nil
+}
+
+// SetLink sets the Link header of w with the next and prev pages, see Link
+func (o PageOptions) SetLink(w http.ResponseWriter, r *http.Request, next, prev *Page) error {
+	link, err := o.Link(r, next, prev)
+	if err != nil || link == "" {
+		return err
+	}
+	w.Header().Set("Link", link)
+	return nil
+}
@@ -0,0 +1,196 @@
+package param
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+// nolint[gochecknoblobals]
+var testPageOptions = PageOptions{Secret: []byte("secret")}
+
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Page
+		wantErr bool
+	}{
+		{name: "defaults", query: "", want: Page{Number: 1, Limit: 20}},
+		{name: "page", query: "page=3&per_page=10", want: Page{Number: 3, Offset: 20, Limit: 10}},
+		{name: "size capped", query: "per_page=1000", want: Page{Number: 1, Limit: 100}},
+		{name: "offset", query: "offset=15", want: Page{Offset: 15, Limit: 20}},
+		{name: "limit", query: "limit=5&page=3", want: Page{Limit: 5}},
+		{name: "empty values", query: "page=&per_page=", want: Page{Number: 1, Limit: 20}},
+		{name: "page zero", query: "page=0", wantErr: true},
+		{name: "negative offset", query: "offset=-1", wantErr: true},
+		{name: "invalid size", query: "per_page=ten", wantErr: true},
+		{name: "invalid cursor", query: "cursor=abc", wantErr: true},
+		{name: "offset overflow", query: "page=9223372036854775807&per_page=100", wantErr: true},
+		{name: "largest page", query: "page=92233720368547759&per_page=100", want: Page{Number: 92233720368547759, Offset: 9223372036854775800, Limit: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Pagination(newQueryRequest(t, tt.query), testPageOptions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Pagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidParam) {
+				t.Errorf("Pagination() error = %v, want ErrInvalidParam", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pagination() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginationCursor(t *testing.T) {
+	s, err := testPageOptions.EncodeCursor(Cursor{Values: []string{"2020-01-02", "7"}, Backward: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Pagination(newQueryRequest(t, "limit=10&cursor="+s), testPageOptions)
+	if err != nil {
+		t.Fatalf("Pagination() error = %v", err)
+	}
+	want := Page{Limit: 10, Cursor: &Cursor{Values: []string{"2020-01-02", "7"}, Backward: true}}
+	if !reflect.DeepEqual(got, want) || !got.Backward() {
+		t.Errorf("Pagination() = %+v, want %+v", got, want)
+	}
+
+	other := PageOptions{Secret: []byte("other")}
+	if _, err := Pagination(newQueryRequest(t, "cursor="+s), other); !errors.Is(err, errCursor) {
+		t.Errorf("Pagination() error = %v, want invalid signature", err)
+	}
+	if _, err := Pagination(newQueryRequest(t, "cursor="+s), PageOptions{}); !errors.Is(err, errCursorSecret) {
+		t.Errorf("Pagination() error = %v, want missing secret", err)
+	}
+}
+
+func TestPageAppendLimit(t *testing.T) {
+	sql := sdb.NewSQLStatement()
+	sql.Append("SELECT id FROM users ORDER BY id")
+	args := Page{Number: 2, Offset: 20, Limit: 20}.AppendLimit(sql, []interface{}{})
+
+	if got, want := sql.Query(), "SELECT id FROM users ORDER BY id LIMIT ? OFFSET ? "; got != want {
+		t.Errorf("AppendLimit() = %q, want %q", got, want)
+	}
+	if want := []interface{}{20, 20}; !reflect.DeepEqual(args, want) {
+		t.Errorf("AppendLimit() args = %v, want %v", args, want)
+	}
+}
+
+func TestPageAppendKeyset(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     Page
+		desc     bool
+		want     string
+		wantArgs []interface{}
+	}{
+		{name: "first page", page: Page{Limit: 10}, want: "SELECT id FROM users LIMIT ? OFFSET ? ", wantArgs: []interface{}{10, 0}},
+		{
+			name: "after", page: Page{Limit: 10}.After("a", "1"),
+			want:     "SELECT id FROM users WHERE (name,id) > (?,?) LIMIT ? ",
+			wantArgs: []interface{}{"a", "1", 10},
+		},
+		{
+			name: "before", page: Page{Limit: 10}.Before("a", "1"),
+			want:     "SELECT id FROM users WHERE (name,id) < (?,?) LIMIT ? ",
+			wantArgs: []interface{}{"a", "1", 10},
+		},
+		{
+			name: "after desc", page: Page{Limit: 10}.After("a", "1"), desc: true,
+			want:     "SELECT id FROM users WHERE (name,id) < (?,?) LIMIT ? ",
+			wantArgs: []interface{}{"a", "1", 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := sdb.NewSQLStatement()
+			sql.Append("SELECT id FROM users")
+			var args []interface{}
+			var err error
+			if tt.desc {
+				args, err = tt.page.AppendKeysetDesc(sql, args, "WHERE", "name", "id")
+			} else {
+				args, err = tt.page.AppendKeyset(sql, args, "WHERE", "name", "id")
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			args = tt.page.AppendLimit(sql, args)
+
+			if got := sql.Query(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPageAppendKeyset_ColumnMismatch(t *testing.T) {
+	sql := sdb.NewSQLStatement()
+	sql.Append("SELECT id FROM users")
+
+	args, err := Page{Limit: 10}.After("a", "1").AppendKeyset(sql, nil, "WHERE", "id")
+	if !errors.Is(err, errKeysetColumns) || args != nil {
+		t.Errorf("AppendKeyset() = %v, %v, want column mismatch", args, err)
+	}
+	if got, want := sql.Query(), "SELECT id FROM users "; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPageNavigation(t *testing.T) {
+	p := Page{Number: 1, Limit: 10}
+	if _, ok := p.Prev(); ok {
+		t.Error("Prev() on first page")
+	}
+	next := p.Next()
+	if want := (Page{Number: 2, Offset: 10, Limit: 10}); next != want {
+		t.Errorf("Next() = %+v, want %+v", next, want)
+	}
+	if prev, ok := next.Prev(); !ok || prev != p {
+		t.Errorf("Prev() = %+v, %v, want %+v", prev, ok, p)
+	}
+	if prev, _ := (Page{Offset: 5, Limit: 10}).Prev(); prev != (Page{Limit: 10}) {
+		t.Errorf("Prev() = %+v, want offset 0", prev)
+	}
+}
+
+func TestPageLink(t *testing.T) {
+	r := httptest.NewRequest("GET", "/users?q=x&page=2&per_page=10", nil)
+	p, err := Pagination(r, testPageOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := p.Next()
+	prev, _ := p.Prev()
+
+	w := httptest.NewRecorder()
+	if err := testPageOptions.SetLink(w, r, &next, &prev); err != nil {
+		t.Fatal(err)
+	}
+	want := `</users?page=3&per_page=10&q=x>; rel="next", </users?page=1&per_page=10&q=x>; rel="prev"`
+	if got := w.Header().Get("Link"); got != want {
+		t.Errorf("Link = %s, want %s", got, want)
+	}
+
+	after := p.After("42")
+	link, err := testPageOptions.Link(r, &after, nil)
+	if err != nil || !strings.HasPrefix(link, "</users?cursor=") || !strings.HasSuffix(link, `&limit=10&q=x>; rel="next"`) {
+		t.Errorf("Link() = %s, %v", link, err)
+	}
+	if _, err := (PageOptions{}).Link(r, &after, nil); !errors.Is(err, errCursorSecret) {
+		t.Errorf("Link() error = %v, want missing secret", err)
+	}
+}
@@ -0,0 +1,60 @@
+package param
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// PathValueFunc returns the path parameter key of r or an empty string, if it is absent.
+// chi.URLParam of chi v4 and v5 can be used directly.
+type PathValueFunc func(r *http.Request, key string) string
+
+// nolint[gochecknoblobals]
+var pathValueFunc PathValueFunc = ChiPathValue
+
+// SetPathValueFunc configures how the path functions and Bind read path parameters,
+// default ChiPathValue. It must be called before serving requests, e.g.
+//
+//	param.SetPathValueFunc(param.StdPathValue)            // Go 1.22 http.ServeMux patterns
+//	param.SetPathValueFunc(chi.URLParam)                  // chi v5
+//	param.SetPathValueFunc(param.VarsPathValue(mux.Vars)) // gorilla/mux
+//
+// nil restores the default.
+func SetPathValueFunc(f PathValueFunc) {
+	if f == nil {
+		f = ChiPathValue
+	}
+	pathValueFunc = f
+}
+
+// ChiPathValue reads path parameters of chi v4
+func ChiPathValue(r *http.Request, key string) string {
+	return chi.URLParam(r, key)
+}
+
+// VarsPathValue adapts routers returning all path parameters as map, like mux.Vars of gorilla/mux
+func VarsPathValue(vars func(r *http.Request) map[string]string) PathValueFunc {
+	return func(r *http.Request, key string) string {
+		return vars(r)[key]
+	}
+}
+
+type pathValuesKey struct{}
+
+// WithPathValues returns a shallow copy of r with path parameters, which take
+// precedence over the configured PathValueFunc. Handlers can be tested without router context.
+func WithPathValues(r *http.Request, values map[string]string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), pathValuesKey{}, values))
+}
+
+// pathValue returns the path parameter key of r
+func pathValue(r *http.Request, key string) string {
+	if values, ok := r.Context().Value(pathValuesKey{}).(map[string]string); ok {
+		if v, found := values[key]; found {
+			return v
+		}
+	}
+	return pathValueFunc(r, key)
+}
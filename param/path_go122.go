@@ -0,0 +1,12 @@
+//go:build go1.22
+// +build go1.22
+
+package param
+
+import "net/http"
+
+// StdPathValue reads path parameters of http.ServeMux patterns, see http.Request.PathValue.
+// Modules declaring a go version before 1.22 need GODEBUG=httpmuxgo121=0 for patterns.
+func StdPathValue(r *http.Request, key string) string {
+	return r.PathValue(key)
+}
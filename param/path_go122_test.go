@@ -0,0 +1,29 @@
+//go:build go1.22
+// +build go1.22
+
+// The module declares go 1.20, which disables the patterns of http.ServeMux.
+//go:debug httpmuxgo121=0
+
+package param
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStdPathValue(t *testing.T) {
+	setPathValueFunc(t, StdPathValue)
+
+	var got uint
+	var err error
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		got, err = Uint(r, "id")
+	})
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/7", nil))
+
+	if err != nil || got != 7 {
+		t.Errorf("Uint() = %v, %v, want 7", got, err)
+	}
+}
@@ -0,0 +1,62 @@
+package param
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setPathValueFunc(t *testing.T, f PathValueFunc) {
+	t.Helper()
+
+	SetPathValueFunc(f)
+	t.Cleanup(func() { SetPathValueFunc(nil) })
+}
+
+func TestWithPathValues(t *testing.T) {
+	r := WithPathValues(httptest.NewRequest("GET", "/", nil), map[string]string{"id": "42"})
+
+	if got, err := Int64(r, "id"); err != nil || got != 42 {
+		t.Errorf("Int64() = %v, %v, want 42", got, err)
+	}
+	if _, err := Int64(r, "other"); err == nil {
+		t.Error("Int64() expected error for missing path parameter")
+	}
+}
+
+func TestVarsPathValue(t *testing.T) {
+	vars := func(r *http.Request) map[string]string {
+		return map[string]string{"day": "04.03.2020"}
+	}
+	setPathValueFunc(t, VarsPathValue(vars))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	if got, err := Date(r, "day"); err != nil || got.Day() != 4 {
+		t.Errorf("Date() = %v, %v", got, err)
+	}
+
+	var dst struct {
+		Day string `path:"day"`
+	}
+	if err := Bind(r, &dst); err != nil || dst.Day != "04.03.2020" {
+		t.Errorf("Bind() = %+v, %v", dst, err)
+	}
+}
+
+func TestSetPathValueFunc_Custom(t *testing.T) {
+	setPathValueFunc(t, func(r *http.Request, key string) string {
+		return r.Header.Get("X-Path-" + key)
+	})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Path-name", "foo")
+	if got, err := String(r, "name"); err != nil || got != "foo" {
+		t.Errorf("String() = %q, %v", got, err)
+	}
+
+	// values of WithPathValues take precedence
+	r = WithPathValues(r, map[string]string{"name": "bar"})
+	if got, err := String(r, "name"); err != nil || got != "bar" {
+		t.Errorf("String() = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,206 @@
+package param
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newFormRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequest("POST", "/?page=2", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		fw, err := w.CreateFormFile(name, name+".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fw.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("POST", "/", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestForm(t *testing.T) {
+	r := newFormRequest(t, "name=foo&amount=1.5&id=1&id=2&ok=true")
+
+	if got, err := FormString(r, "name"); err != nil || got != "foo" {
+		t.Errorf("FormString() = %q, %v", got, err)
+	}
+	if got, err := FormFloat64(r, "amount"); err != nil || got != 1.5 {
+		t.Errorf("FormFloat64() = %v, %v", got, err)
+	}
+	if got, err := FormIntArray(r, "id"); err != nil || !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("FormIntArray() = %v, %v", got, err)
+	}
+	if got, err := FormBool(r, "ok"); err != nil || !got {
+		t.Errorf("FormBool() = %v, %v", got, err)
+	}
+
+	// query parameters are not form parameters
+	_, err := FormInt(r, "page")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Source != SourceForm || !errors.Is(err, ErrMissingParam) {
+		t.Errorf("FormInt() error = %v, want missing form parameter", err)
+	}
+	_, err = FormUint8(r, "name")
+	if !errors.As(err, &perr) || perr.Value != "foo" || perr.Type != "uint8" {
+		t.Errorf("FormUint8() error = %v, want invalid uint8", err)
+	}
+}
+
+func TestForm_MaxBodySize(t *testing.T) {
+	old := DefaultFormOptions
+	DefaultFormOptions.MaxBodySize = 8
+	t.Cleanup(func() { DefaultFormOptions = old })
+
+	_, err := FormString(newFormRequest(t, "name=much+too+long"), "name")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Source != SourceForm || errors.Is(err, ErrMissingParam) {
+		t.Errorf("FormString() error = %v, want body error", err)
+	}
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestFormFile_MaxBodySize(t *testing.T) {
+	old := DefaultFormOptions
+	DefaultFormOptions.MaxBodySize = 1 << 10
+	t.Cleanup(func() { DefaultFormOptions = old })
+
+	r := newMultipartRequest(t, nil, map[string]string{"doc": strings.Repeat("x", 1<<20)})
+	body := &countingReader{r: r.Body}
+	r.Body = io.NopCloser(body)
+
+	_, err := FormFile(r, "doc", 0)
+	var merr *http.MaxBytesError
+	if !errors.As(err, &merr) || merr.Limit != 1<<10 {
+		t.Errorf("FormFile() error = %v, want MaxBytesError", err)
+	}
+	if body.n >= 1<<16 {
+		t.Errorf("read %d bytes of the body, want to stop at the limit", body.n)
+	}
+}
+
+func TestFormFile(t *testing.T) {
+	r := newMultipartRequest(t, map[string]string{"title": "report"}, map[string]string{"doc": "0123456789"})
+
+	if got, err := FormString(r, "title"); err != nil || got != "report" {
+		t.Errorf("FormString() = %q, %v", got, err)
+	}
+
+	fh, err := FormFile(r, "doc", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fh.Filename != "doc.txt" || fh.Size != 10 {
+		t.Errorf("FormFile() = %s with %d bytes", fh.Filename, fh.Size)
+	}
+
+	_, err = FormFile(r, "doc", 9)
+	var cerr *ConstraintError
+	if !errors.As(err, &cerr) || cerr.Constraint != "maxsize" || err.Error() != `form parameter "doc": invalid value "doc.txt", expected file: must be at most 9 bytes` {
+		t.Errorf("FormFile() error = %v, want maxsize", err)
+	}
+
+	if _, err := FormFiles(r, "none", 0); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("FormFiles() error = %v, want missing", err)
+	}
+	if _, err := FormFile(newFormRequest(t, "doc=x"), "doc", 0); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("FormFile() error = %v, want missing for url encoded form", err)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Request-Id", "42")
+	r.Header.Add("X-Tag", "a")
+	r.Header.Add("X-Tag", "b")
+
+	if got, err := HeaderInt64(r, "x-request-id"); err != nil || got != 42 {
+		t.Errorf("HeaderInt64() = %v, %v", got, err)
+	}
+	if got, err := HeaderStringArray(r, "X-Tag"); err != nil || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("HeaderStringArray() = %v, %v", got, err)
+	}
+	_, err := HeaderBool(r, "X-Missing")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Source != SourceHeader || !errors.Is(err, ErrMissingParam) {
+		t.Errorf("HeaderBool() error = %v, want missing header", err)
+	}
+}
+
+func TestCookie(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	r.AddCookie(&http.Cookie{Name: "tz", Value: "60"})
+
+	if got, err := CookieString(r, "session"); err != nil || got != "abc" {
+		t.Errorf("CookieString() = %q, %v", got, err)
+	}
+	if got, err := CookieInt(r, "tz"); err != nil || got != 60 {
+		t.Errorf("CookieInt() = %v, %v", got, err)
+	}
+	_, err := CookieInt(r, "session")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Source != SourceCookie || perr.Value != "abc" {
+		t.Errorf("CookieInt() error = %v, want invalid cookie", err)
+	}
+}
+
+func TestBind_Sources(t *testing.T) {
+	r := newFormRequest(t, "name=foo&tag=a&tag=b")
+	r.Header.Set("X-Request-Id", "7")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	var dst struct {
+		Page    int      `query:"page"`
+		Name    string   `form:"name"`
+		Tags    []string `form:"tag"`
+		Request uint64   `header:"X-Request-Id"`
+		Session *string  `cookie:"session"`
+		Lang    *string  `cookie:"lang"`
+	}
+	if err := Bind(r, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Page != 2 || dst.Name != "foo" || !reflect.DeepEqual(dst.Tags, []string{"a", "b"}) || dst.Request != 7 ||
+		dst.Session == nil || *dst.Session != "abc" || dst.Lang != nil {
+		t.Errorf("got %+v", dst)
+	}
+}
@@ -0,0 +1,153 @@
+package param
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/seambiz/seambiz/stime"
+)
+
+// TimeFormat configures the accepted layouts and the timezone of date and time parameters
+type TimeFormat struct {
+	// DateLayouts accepted by Date, QueryDate and QueryDateRange
+	DateLayouts []string
+	// TimeLayouts accepted by QueryTime
+	TimeLayouts []string
+	// Location of values without zone offset and of unix timestamps, default UTC like stime.ParseDate
+	Location *time.Location
+}
+
+// DefaultTimeFormat is used by the package level date and time functions. It accepts
+// german and ISO dates, and additionally RFC 3339, german and MySQL date times.
+// nolint[gochecknoblobals]
+var DefaultTimeFormat = TimeFormat{
+	DateLayouts: []string{stime.DateLayout, "2006-01-02"},
+	TimeLayouts: []string{
+		time.RFC3339Nano, stime.DateTimeLayout, "2006-01-02 15:04:05", "2006-01-02T15:04:05",
+		stime.DateLayout, "2006-01-02",
+	},
+}
+
+var (
+	errTimeLayout = errors.New("no matching layout")
+	errTimeRange  = errors.New("must not be before the start")
+)
+
+func (f TimeFormat) location() *time.Location {
+	if f.Location == nil {
+		return time.UTC
+	}
+	return f.Location
+}
+
+func (f TimeFormat) parse(layouts []string, s string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, f.location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errTimeLayout
+}
+
+// Date returns a path parameter as date at midnight
+func (f TimeFormat) Date(r *http.Request, key string) (time.Time, error) {
+	s := pathValue(r, key)
+	t, err := f.parse(f.DateLayouts, s)
+	return t, pathError(key, s, "date", err)
+}
+
+// QueryDate returns a query parameter as date at midnight
+func (f TimeFormat) QueryDate(r *http.Request, key string) (time.Time, error) {
+	s, err := QueryString(r, key)
+	if err != nil {
+		return time.Time{}, missingError(SourceQuery, key, "date")
+	}
+	t, err := f.parse(f.DateLayouts, s)
+	return t, newError(SourceQuery, key, s, "date", err)
+}
+
+// QueryTime returns a query parameter as time
+func (f TimeFormat) QueryTime(r *http.Request, key string) (time.Time, error) {
+	s, err := QueryString(r, key)
+	if err != nil {
+		return time.Time{}, missingError(SourceQuery, key, "time")
+	}
+	t, err := f.parse(f.TimeLayouts, s)
+	if err != nil && strings.Contains(s, " ") {
+		// replace + of the zone offset stripped out during url parse stage
+		t, err = f.parse(f.TimeLayouts, strings.Replace(s, " ", "+", 1))
+	}
+	return t, newError(SourceQuery, key, s, "time", err)
+}
+
+// QueryUnix returns a query parameter with unix timestamp in seconds as time
+func (f TimeFormat) QueryUnix(r *http.Request, key string) (time.Time, error) {
+	n, err := QueryInt64(r, key)
+	if err != nil {
+		return time.Time{}, retype(err, "unix")
+	}
+	return time.Unix(n, 0).In(f.location()), nil
+}
+
+// QueryDateRange returns the optional query parameters fromKey as start of its day and
+// toKey as end of its day, see stime.ParseDate. Absent parameters are returned as zero time.
+// A range ending before its start is an error of toKey.
+func (f TimeFormat) QueryDateRange(r *http.Request, fromKey, toKey string) (from, to time.Time, err error) {
+	var errs MultiError
+	if hasQuery(r, fromKey) {
+		if from, err = f.QueryDate(r, fromKey); err != nil {
+			errs = append(errs, err.(*Error))
+		}
+	}
+	if hasQuery(r, toKey) {
+		if to, err = f.QueryDate(r, toKey); err != nil {
+			errs = append(errs, err.(*Error))
+		} else {
+			to = stime.EndOfDay(to)
+		}
+	}
+	if len(errs) > 0 {
+		return time.Time{}, time.Time{}, errs
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		v, _ := QueryString(r, toKey)
+		return time.Time{}, time.Time{}, &Error{Key: toKey, Source: SourceQuery, Value: v, Type: "date", Err: errTimeRange}
+	}
+	return from, to, nil
+}
+
+// retype sets the expected type of a parameter error
+func retype(err error, typ string) error {
+	var perr *Error
+	if errors.As(err, &perr) {
+		perr.Type = typ
+	}
+	return err
+}
+
+// Date returns a path parameter as date at midnight, see DefaultTimeFormat
+func Date(r *http.Request, key string) (time.Time, error) {
+	return DefaultTimeFormat.Date(r, key)
+}
+
+// QueryDate returns a query parameter as date at midnight, see DefaultTimeFormat
+func QueryDate(r *http.Request, key string) (time.Time, error) {
+	return DefaultTimeFormat.QueryDate(r, key)
+}
+
+// QueryTime returns a query parameter as time, see DefaultTimeFormat
+func QueryTime(r *http.Request, key string) (time.Time, error) {
+	return DefaultTimeFormat.QueryTime(r, key)
+}
+
+// QueryUnix returns a query parameter with unix timestamp in seconds as time
+func QueryUnix(r *http.Request, key string) (time.Time, error) {
+	return DefaultTimeFormat.QueryUnix(r, key)
+}
+
+// QueryDateRange returns a date range from two optional query parameters, see TimeFormat.QueryDateRange
+func QueryDateRange(r *http.Request, fromKey, toKey string) (from, to time.Time, err error) {
+	return DefaultTimeFormat.QueryDateRange(r, fromKey, toKey)
+}
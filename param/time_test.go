@@ -0,0 +1,138 @@
+package param
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seambiz/seambiz/stime"
+)
+
+func TestQueryDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    time.Time
+		wantErr error
+	}{
+		{name: "german", query: "d=04.03.2020", want: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "iso", query: "d=2020-03-04", want: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid", query: "d=2020-13-01", wantErr: errTimeLayout},
+		{name: "datetime", query: "d=2020-03-04T10:00:00Z", wantErr: errTimeLayout},
+		{name: "missing", query: "x=1", wantErr: ErrMissingParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueryDate(newQueryRequest(t, tt.query), "d")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("QueryDate() error = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("QueryDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryDate_MatchesStime(t *testing.T) {
+	got, err := QueryDate(newQueryRequest(t, "d=31.12.2019"), "d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := stime.ParseDate("31.12.2019", false)
+	if uint(got.Unix()) != want {
+		t.Errorf("QueryDate() = %d, stime.ParseDate() = %d", got.Unix(), want)
+	}
+}
+
+func TestDate(t *testing.T) {
+	req, key := newParamRequest(t, "04.03.2020")
+	got, err := Date(req, key)
+	if err != nil || !got.Equal(time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date() = %v, %v", got, err)
+	}
+
+	_, err = Date(req, "none")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Type != "date" || !errors.Is(err, ErrMissingParam) {
+		t.Errorf("Date() error = %v, want missing date", err)
+	}
+}
+
+func TestQueryTime(t *testing.T) {
+	loc, err := time.LoadLocation(stime.TzGermany)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := DefaultTimeFormat
+	f.Location = loc
+
+	tests := []struct {
+		query string
+		want  time.Time
+	}{
+		{query: "t=2020-03-04T05:06:07.5Z", want: time.Date(2020, 3, 4, 5, 6, 7, 500000000, time.UTC)},
+		{query: "t=04.03.2020+05:06:07", want: time.Date(2020, 3, 4, 5, 6, 7, 0, loc)},
+		{query: "t=2020-03-04+05:06:07", want: time.Date(2020, 3, 4, 5, 6, 7, 0, loc)},
+		{query: "t=04.03.2020", want: time.Date(2020, 3, 4, 0, 0, 0, 0, loc)},
+		{query: "t=2020-03-04T05:06:07+01:00", want: time.Date(2020, 3, 4, 4, 6, 7, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := f.QueryTime(newQueryRequest(t, tt.query), "t")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("QueryTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryUnix(t *testing.T) {
+	got, err := QueryUnix(newQueryRequest(t, "ts=1583298367"), "ts")
+	if err != nil || !got.Equal(time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)) || got.Location() != time.UTC {
+		t.Errorf("QueryUnix() = %v, %v", got, err)
+	}
+
+	_, err = QueryUnix(newQueryRequest(t, "ts=yesterday"), "ts")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Type != "unix" || perr.Value != "yesterday" {
+		t.Errorf("QueryUnix() error = %v, want invalid unix", err)
+	}
+}
+
+func TestQueryDateRange(t *testing.T) {
+	from, to, err := QueryDateRange(newQueryRequest(t, "from=01.03.2020&to=2020-03-31"), "from", "to")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("from = %v, want %v", from, want)
+	}
+	if want := time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC); !to.Equal(want) {
+		t.Errorf("to = %v, want %v", to, want)
+	}
+	wantTo, _ := stime.ParseDate("31.03.2020", true)
+	if uint(to.Unix()) != wantTo {
+		t.Errorf("to = %d, stime.ParseDate() = %d", to.Unix(), wantTo)
+	}
+
+	from, to, err = QueryDateRange(newQueryRequest(t, "to=31.03.2020"), "from", "to")
+	if err != nil || !from.IsZero() || to.IsZero() {
+		t.Errorf("QueryDateRange() = %v, %v, %v, want open start", from, to, err)
+	}
+
+	_, _, err = QueryDateRange(newQueryRequest(t, "from=02.03.2020&to=01.03.2020"), "from", "to")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Key != "to" || !errors.Is(err, errTimeRange) {
+		t.Errorf("QueryDateRange() error = %v, want invalid range", err)
+	}
+
+	_, _, err = QueryDateRange(newQueryRequest(t, "from=x&to=y"), "from", "to")
+	var merr MultiError
+	if !errors.As(err, &merr) || len(merr) != 2 {
+		t.Errorf("QueryDateRange() error = %v, want 2 errors", err)
+	}
+}
@@ -0,0 +1,337 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ConstraintError is the cause of an Error for a value violating a constraint
+type ConstraintError struct {
+	// Constraint name as used in the validate struct tag, e.g. min or oneof
+	Constraint string
+	// Limit of the constraint, e.g. the minimum
+	Limit string
+}
+
+func (e *ConstraintError) Error() string {
+	switch e.Constraint {
+	case "min":
+		return "must be at least " + e.Limit
+	case "max":
+		return "must be at most " + e.Limit
+	case "oneof":
+		return "must be one of " + e.Limit
+	case "regex":
+		return "must match " + e.Limit
+	case "maxlen":
+		return "must be at most " + e.Limit + " characters long"
+	case "nonempty":
+		return "must not be empty"
+	case "maxitems":
+		return "must have at most " + e.Limit + " items"
+	case "maxsize":
+		return "must be at most " + e.Limit + " bytes"
+	}
+	return "violates " + e.Constraint + " " + e.Limit
+}
+
+// Constraint restricts the values of a parameter, see Validate and the validate struct tag of Bind.
+// Constraints for single values are applied to every item of a slice.
+type Constraint struct {
+	name  string
+	limit string
+	// list constraints check the slice, not its items
+	list  bool
+	check func(v reflect.Value) bool
+}
+
+func (c Constraint) err() *ConstraintError {
+	return &ConstraintError{Constraint: c.name, Limit: c.limit}
+}
+
+// Number is the type of the limit of Min and Max
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Min requires numbers >= min. Integers are compared exactly, floats as float64.
+func Min[N Number](min N) Constraint {
+	return minLimit(newNumLimit(reflect.ValueOf(min)))
+}
+
+// Max requires numbers <= max. Integers are compared exactly, floats as float64.
+func Max[N Number](max N) Constraint {
+	return maxLimit(newNumLimit(reflect.ValueOf(max)))
+}
+
+func minLimit(l numLimit) Constraint {
+	return Constraint{name: "min", limit: l.s, check: func(v reflect.Value) bool {
+		if isFloat(v) {
+			return v.Float() >= l.f
+		}
+		c, ok := l.cmp(v)
+		return !ok || c >= 0
+	}}
+}
+
+func maxLimit(l numLimit) Constraint {
+	return Constraint{name: "max", limit: l.s, check: func(v reflect.Value) bool {
+		if isFloat(v) {
+			return v.Float() <= l.f
+		}
+		c, ok := l.cmp(v)
+		return !ok || c <= 0
+	}}
+}
+
+// OneOf requires values, whose string representation is one of values
+func OneOf(values ...string) Constraint {
+	return Constraint{name: "oneof", limit: strings.Join(values, ", "), check: func(v reflect.Value) bool {
+		s := fmt.Sprint(v.Interface())
+		for _, o := range values {
+			if s == o {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// Regex requires strings matching re
+func Regex(re *regexp.Regexp) Constraint {
+	return Constraint{name: "regex", limit: re.String(), check: func(v reflect.Value) bool {
+		return v.Kind() != reflect.String || re.MatchString(v.String())
+	}}
+}
+
+// MaxLen requires strings of at most n characters
+func MaxLen(n int) Constraint {
+	return Constraint{name: "maxlen", limit: strconv.Itoa(n), check: func(v reflect.Value) bool {
+		return v.Kind() != reflect.String || utf8.RuneCountInString(v.String()) <= n
+	}}
+}
+
+// NonEmpty requires non-empty strings and slices
+func NonEmpty() Constraint {
+	return Constraint{name: "nonempty", list: true, check: func(v reflect.Value) bool {
+		switch v.Kind() {
+		case reflect.String:
+			return v.Len() > 0
+		case reflect.Slice:
+			if v.Len() == 0 {
+				return false
+			}
+			if v.Type().Elem().Kind() == reflect.String {
+				for i := 0; i < v.Len(); i++ {
+					if v.Index(i).Len() == 0 {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}}
+}
+
+// MaxItems requires slices with at most n items
+func MaxItems(n int) Constraint {
+	return Constraint{name: "maxitems", limit: strconv.Itoa(n), list: true, check: func(v reflect.Value) bool {
+		return v.Kind() != reflect.Slice || v.Len() <= n
+	}}
+}
+
+func formatLimit(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// numLimit is the limit of Min and Max, exact for integers and decimal tag values
+type numLimit struct {
+	s string
+	f float64
+	// r is nil for infinite limits
+	r *big.Rat
+}
+
+func newNumLimit(v reflect.Value) numLimit {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numLimit{s: strconv.FormatInt(v.Int(), 10), f: float64(v.Int()), r: new(big.Rat).SetInt64(v.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numLimit{s: strconv.FormatUint(v.Uint(), 10), f: float64(v.Uint()), r: new(big.Rat).SetUint64(v.Uint())}
+	}
+	return numLimit{s: formatLimit(v.Float()), f: v.Float(), r: new(big.Rat).SetFloat64(v.Float())}
+}
+
+// parseNumLimit parses the limit of a validate tag
+func parseNumLimit(s string) (numLimit, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return numLimit{}, err
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		r = new(big.Rat).SetFloat64(f)
+	}
+	return numLimit{s: s, f: f, r: r}, nil
+}
+
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// cmp compares the integer v with l and returns false, if v is no integer
+func (l numLimit) cmp(v reflect.Value) (int, bool) {
+	var n *big.Rat
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = new(big.Rat).SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = new(big.Rat).SetUint64(v.Uint())
+	default:
+		return 0, false
+	}
+	if l.r == nil {
+		// only NaN and infinite limits have no rational value
+		if l.f > 0 {
+			return -1, true
+		}
+		return 1, true
+	}
+	return n.Cmp(l.r), true
+}
+
+// Validate checks value of the parameter key from source against constraints
+// and returns an *Error with a *ConstraintError cause for the first violation.
+func Validate(source, key string, value interface{}, constraints ...Constraint) error {
+	return validate(source, key, reflect.ValueOf(value), constraints)
+}
+
+func validate(source, key string, v reflect.Value, constraints []Constraint) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	for _, c := range constraints {
+		if c.list || v.Kind() != reflect.Slice {
+			if !c.check(v) {
+				return constraintError(source, key, v, c)
+			}
+			continue
+		}
+		for i := 0; i < v.Len(); i++ {
+			if item := v.Index(i); !c.check(item) {
+				return constraintError(source, key, item, c)
+			}
+		}
+	}
+	return nil
+}
+
+func constraintError(source, key string, v reflect.Value, c Constraint) error {
+	e := &Error{Key: key, Source: source, Type: v.Type().String(), Err: c.err()}
+	if v.Kind() != reflect.Slice {
+		e.Value = fmt.Sprint(v.Interface())
+	}
+	return e
+}
+
+// QueryValid reads the query parameter key with get, e.g. QueryInt, and validates it.
+func QueryValid[T any](r *http.Request, key string, get func(*http.Request, string) (T, error), constraints ...Constraint) (T, error) {
+	v, err := get(r, key)
+	if err == nil {
+		err = Validate(SourceQuery, key, v, constraints...)
+	}
+	return v, err
+}
+
+// PathValid reads the path parameter key with get, e.g. Int64, and validates it.
+func PathValid[T any](r *http.Request, key string, get func(*http.Request, string) (T, error), constraints ...Constraint) (T, error) {
+	v, err := get(r, key)
+	if err == nil {
+		err = Validate(SourcePath, key, v, constraints...)
+	}
+	return v, err
+}
+
+// TagValidate struct tag with constraints for Bind, e.g. `validate:"min=1,max=100"`.
+// Supported are min, max, oneof (values separated by |), maxlen, nonempty, maxitems
+// and regex, which must be last, because the expression may contain commas.
+const TagValidate = "validate"
+
+var errValidateTag = errors.New("param: invalid validate tag")
+
+// nolint[gochecknoblobals]
+var tagConstraints sync.Map // map[string][]Constraint
+
+// parseConstraints parses and caches a validate tag
+func parseConstraints(tag string) ([]Constraint, error) {
+	if cs, ok := tagConstraints.Load(tag); ok {
+		return cs.([]Constraint), nil
+	}
+
+	var cs []Constraint
+	rest := tag
+	for rest != "" {
+		var item string
+		if strings.HasPrefix(rest, "regex=") {
+			item, rest = rest, ""
+		} else if i := strings.IndexByte(rest, ','); i >= 0 {
+			item, rest = rest[:i], rest[i+1:]
+		} else {
+			item, rest = rest, ""
+		}
+
+		name, arg, _ := strings.Cut(strings.TrimSpace(item), "=")
+		var err error
+		switch name {
+		case "min", "max":
+			var l numLimit
+			if l, err = parseNumLimit(arg); err == nil {
+				if name == "min" {
+					cs = append(cs, minLimit(l))
+				} else {
+					cs = append(cs, maxLimit(l))
+				}
+			}
+		case "oneof":
+			cs = append(cs, OneOf(strings.Split(arg, "|")...))
+		case "regex":
+			var re *regexp.Regexp
+			if re, err = regexp.Compile(arg); err == nil {
+				cs = append(cs, Regex(re))
+			}
+		case "maxlen", "maxitems":
+			var n int
+			if n, err = strconv.Atoi(arg); err == nil {
+				if name == "maxlen" {
+					cs = append(cs, MaxLen(n))
+				} else {
+					cs = append(cs, MaxItems(n))
+				}
+			}
+		case "nonempty":
+			cs = append(cs, NonEmpty())
+		default:
+			err = errors.New("unknown constraint " + strconv.Quote(name))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", errValidateTag, tag, err)
+		}
+	}
+
+	tagConstraints.Store(tag, cs)
+	return cs, nil
+}
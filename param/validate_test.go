@@ -0,0 +1,146 @@
+package param
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestValidateTagLimitExact(t *testing.T) {
+	var dst struct {
+		ID int64 `query:"id" validate:"max=9007199254740992"`
+	}
+	err := Bind(newBindRequest(t, nil, "id=9007199254740993"), &dst)
+	var cerr *ConstraintError
+	if !errors.As(err, &cerr) || cerr.Limit != "9007199254740992" {
+		t.Errorf("Bind() error = %v, want max constraint", err)
+	}
+	if err := Bind(newBindRequest(t, nil, "id=9007199254740992"), &dst); err != nil {
+		t.Errorf("Bind() error = %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       interface{}
+		constraints []Constraint
+		wantErr     string
+	}{
+		{name: "min ok", value: 1, constraints: []Constraint{Min(1)}},
+		{name: "min", value: 0, constraints: []Constraint{Min(1)}, wantErr: `query parameter "p": invalid value "0", expected int: must be at least 1`},
+		{name: "max", value: uint8(101), constraints: []Constraint{Min(1), Max(100)}, wantErr: `query parameter "p": invalid value "101", expected uint8: must be at most 100`},
+		{name: "max float", value: 1.5, constraints: []Constraint{Max(1.25)}, wantErr: `query parameter "p": invalid value "1.5", expected float64: must be at most 1.25`},
+		{name: "max int64 exact", value: int64(9007199254740993), constraints: []Constraint{Max(int64(9007199254740992))}, wantErr: `query parameter "p": invalid value "9007199254740993", expected int64: must be at most 9007199254740992`},
+		{name: "min uint64 exact", value: uint64(18446744073709551614), constraints: []Constraint{Min(uint64(18446744073709551615))}, wantErr: `query parameter "p": invalid value "18446744073709551614", expected uint64: must be at least 18446744073709551615`},
+		{name: "max uint64 ok", value: uint64(18446744073709551615), constraints: []Constraint{Max(uint64(18446744073709551615))}},
+		{name: "fractional limit", value: 2, constraints: []Constraint{Max(1.5)}, wantErr: `query parameter "p": invalid value "2", expected int: must be at most 1.5`},
+		{name: "oneof ok", value: "asc", constraints: []Constraint{OneOf("asc", "desc")}},
+		{name: "oneof int", value: 3, constraints: []Constraint{OneOf("1", "2")}, wantErr: `query parameter "p": invalid value "3", expected int: must be one of 1, 2`},
+		{name: "regex", value: "ab1", constraints: []Constraint{Regex(regexp.MustCompile(`^[a-z]+$`))}, wantErr: `query parameter "p": invalid value "ab1", expected string: must match ^[a-z]+$`},
+		{name: "maxlen runes", value: "äöü", constraints: []Constraint{MaxLen(3)}},
+		{name: "maxlen", value: "abcd", constraints: []Constraint{MaxLen(3)}, wantErr: `query parameter "p": invalid value "abcd", expected string: must be at most 3 characters long`},
+		{name: "nonempty", value: "", constraints: []Constraint{NonEmpty()}, wantErr: `query parameter "p": invalid value "", expected string: must not be empty`},
+		{name: "nonempty slice", value: []int{}, constraints: []Constraint{NonEmpty()}, wantErr: `query parameter "p": invalid value "", expected []int: must not be empty`},
+		{name: "nonempty slice item", value: []string{"a", ""}, constraints: []Constraint{NonEmpty()}, wantErr: `query parameter "p": invalid value "", expected []string: must not be empty`},
+		{name: "maxitems", value: []int{1, 2, 3}, constraints: []Constraint{MaxItems(2)}, wantErr: `query parameter "p": invalid value "", expected []int: must have at most 2 items`},
+		{name: "slice items", value: []int{1, 20, 3}, constraints: []Constraint{MaxItems(3), Max(10)}, wantErr: `query parameter "p": invalid value "20", expected int: must be at most 10`},
+		{name: "nil pointer", value: (*int)(nil), constraints: []Constraint{Min(1)}},
+		{name: "pointer", value: func() *int { i := 0; return &i }(), constraints: []Constraint{Min(1)}, wantErr: `query parameter "p": invalid value "0", expected int: must be at least 1`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(SourceQuery, "p", tt.value, tt.constraints...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+			}
+			var cerr *ConstraintError
+			if !errors.Is(err, ErrInvalidParam) || !errors.As(err, &cerr) {
+				t.Errorf("Validate() error = %v, want *Error with *ConstraintError", err)
+			}
+		})
+	}
+}
+
+func TestQueryValid(t *testing.T) {
+	r := newQueryRequest(t, "limit=500&sort=name")
+
+	_, err := QueryValid(r, "limit", QueryInt, Min(1), Max(100))
+	var cerr *ConstraintError
+	if !errors.As(err, &cerr) || cerr.Constraint != "max" || cerr.Limit != "100" {
+		t.Errorf("QueryValid() error = %v, want max constraint", err)
+	}
+
+	sort, err := QueryValid(r, "sort", QueryString, OneOf("name", "date"))
+	if err != nil || sort != "name" {
+		t.Errorf("QueryValid() = %q, %v, want %q", sort, err, "name")
+	}
+
+	if _, err := QueryValid(r, "none", QueryInt, Min(1)); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("QueryValid() error = %v, want missing", err)
+	}
+
+	req, key := newParamRequest(t, "0")
+	if _, err := PathValid(req, key, Int64, Min(1)); !errors.As(err, &cerr) {
+		t.Errorf("PathValid() error = %v, want min constraint", err)
+	}
+}
+
+func TestBind_Validate(t *testing.T) {
+	type filter struct {
+		Limit int      `query:"limit" validate:"min=1,max=100"`
+		Sort  *string  `query:"sort" validate:"oneof=name|date"`
+		Code  string   `query:"code" validate:"maxlen=4,regex=^[A-Z]{1,3}(,[A-Z])?$"`
+		IDs   []uint   `query:"id" validate:"nonempty,maxitems=2,max=10"`
+		Tags  []string `query:"tag" validate:"maxitems=5"`
+	}
+
+	var ok filter
+	if err := Bind(newBindRequest(t, nil, "limit=10&sort=date&code=AB,C&id=1&id=2"), &ok); err != nil {
+		t.Fatal(err)
+	}
+
+	var bad filter
+	err := Bind(newBindRequest(t, nil, "limit=0&sort=size&code=abc&id=1&id=2&id=3"), &bad)
+	var merr MultiError
+	if !errors.As(err, &merr) || len(merr) != 4 {
+		t.Fatalf("Bind() error = %v, want 4 errors", err)
+	}
+	for i, want := range []string{"min", "oneof", "regex", "maxitems"} {
+		var cerr *ConstraintError
+		if !errors.As(merr[i], &cerr) || cerr.Constraint != want {
+			t.Errorf("error %d = %v, want %s constraint", i, merr[i], want)
+		}
+	}
+
+	var missing filter
+	err = Bind(newBindRequest(t, nil, "limit=1&code=A"), &missing)
+	if !errors.As(err, &merr) || len(merr) != 1 || merr[0].Key != "id" || !errors.Is(merr[0], ErrMissingParam) {
+		t.Errorf("Bind() error = %v, want missing id", err)
+	}
+}
+
+func TestBind_InvalidValidateTag(t *testing.T) {
+	for _, dst := range []interface{}{
+		&struct {
+			A int `query:"a" validate:"min=x"`
+		}{},
+		&struct {
+			A string `query:"a" validate:"regex=("`
+		}{},
+		&struct {
+			A string `query:"a" validate:"unknown"`
+		}{},
+	} {
+		err := Bind(newBindRequest(t, nil, "a=1"), dst)
+		if !errors.Is(err, errValidateTag) {
+			t.Errorf("Bind(%T) error = %v, want invalid tag", dst, err)
+		}
+	}
+}
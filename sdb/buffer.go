@@ -19,6 +19,7 @@ const (
 // nolint[gochecknoblobals]
 var sqlBuffer = &sync.Pool{
 	New: func() interface{} {
+		sqlPoolCounters.newBuffer(1024)
 		return &SQLStatement{
 			buffer: make([]byte, 0, 1024),
 		}
@@ -29,27 +30,42 @@ var sqlBuffer = &sync.Pool{
 type SQLStatement struct {
 	buffer       []byte
 	fieldsCalled bool
+	// released stack of the Release call in debug mode
+	released []byte
 }
 
 // NewSQLStatement return bytebuffer for a statement
 func NewSQLStatement() *SQLStatement {
 	s := sqlBuffer.Get().(*SQLStatement)
+	sqlPoolCounters.get(cap(s.buffer))
 	// Defensively reset to ensure clean state, even if previous user forgot to Release()
 	// This is safe because we own this buffer instance now from the pool
 	if len(s.buffer) != 0 || s.fieldsCalled {
 		s.Reset()
 	}
+	if poolDebugEnabled() {
+		trackStatement(s)
+	}
 	return s
 }
 
 // Release resets the statement and returns it to the pool.
+// Statements grown beyond the pool max capacity are left to the garbage collector.
+// In debug mode, see EnablePoolDebug, the statement is marked as released instead.
 func (s *SQLStatement) Release() {
 	s.Reset()
-	sqlBuffer.Put(s)
+	if poolDebugEnabled() {
+		releaseStatement(s)
+		return
+	}
+	if sqlPoolCounters.put(cap(s.buffer)) {
+		sqlBuffer.Put(s)
+	}
 }
 
 // String returns a string representation
 func (s *SQLStatement) String() string {
+	s.checkReleased()
 	return string(s.buffer)
 }
 
@@ -60,6 +76,7 @@ func (s *SQLStatement) Query() string {
 }
 
 func (s *SQLStatement) Bytes() []byte {
+	s.checkReleased()
 	// Capture length before making slice to avoid race with concurrent Reset()
 	n := len(s.buffer)
 	result := make([]byte, n)
@@ -169,6 +186,7 @@ func (s *SQLStatement) appendUInt(n uint) {
 
 // AppendInt appends a string to the sql statement
 func (s *SQLStatement) AppendInt(n int) *SQLStatement {
+	s.checkReleased()
 	s.buffer = strconv.AppendInt(s.buffer, int64(n), 10)
 
 	return s
@@ -176,18 +194,21 @@ func (s *SQLStatement) AppendInt(n int) *SQLStatement {
 
 // Reset the underlying buffer.
 func (s *SQLStatement) Reset() {
+	s.checkReleased()
 	s.buffer = s.buffer[:0]
 	s.fieldsCalled = false
 }
 
 // Write implements io.Writer - it appends p to ByteBuffer.B
 func (s *SQLStatement) Write(p []byte) (int, error) {
+	s.checkReleased()
 	s.buffer = append(s.buffer, p...)
 	return len(p), nil
 }
 
 // WriteString appends s to ByteBuffer.B.
 func (s *SQLStatement) WriteString(str string) (int, error) {
+	s.checkReleased()
 	s.buffer = append(s.buffer, str...)
 	return len(str), nil
 }
@@ -39,9 +39,8 @@ func TestAppendRaw(t *testing.T) {
 }
 
 func TestAppendInt(t *testing.T) {
-	sql := sdb.NewSQLStatement()
-
 	for i := 0; i < 100; i++ {
+		sql := sdb.NewSQLStatement()
 		n := int(rand.Int31())
 		sql.AppendInt(n)
 
@@ -2,6 +2,7 @@ package sdb
 
 import (
 	"errors"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,8 @@ import (
 // TimeFormat Sandard MySQL datetime format
 const (
 	TimeFormat = time.RFC3339
+
+	mysqlTimeFormat = "2006-01-02 15:04:05"
 )
 
 // ToUnsafeString converts b to string without memory allocations.
@@ -25,10 +28,43 @@ func ToUnsafeString(b []byte) string {
 // ParseUint parses uint from buf.
 func ParseUint(buf []byte) (int, error) {
 	v, n, err := parseUintBuf(buf)
+	if err != nil {
+		return -1, err
+	}
 	if n != len(buf) {
 		return -1, errUnexpectedTrailingChar
 	}
-	return v, err
+	return v, nil
+}
+
+// ParseUint64 parses uint64 from buf without memory allocations.
+func ParseUint64(buf []byte) (uint64, error) {
+	v, n, err := parseUint64Buf(buf, math.MaxUint64)
+	if err != nil {
+		return 0, err
+	}
+	if n != len(buf) {
+		return 0, errUnexpectedTrailingChar
+	}
+	return v, nil
+}
+
+// ParseInt parses a signed int from buf without memory allocations.
+func ParseInt(buf []byte) (int, error) {
+	v, err := parseIntBuf(buf, strconv.IntSize)
+	return int(v), err
+}
+
+// ParseInt64 parses int64 from buf without memory allocations.
+func ParseInt64(buf []byte) (int64, error) {
+	return parseIntBuf(buf, 64)
+}
+
+// ParseFloat parses float64 from buf.
+//
+// The errors returned do not reference buf, so buf may be reused afterwards.
+func ParseFloat(buf []byte) (float64, error) {
+	return parseFloatBuf(buf, 64)
 }
 
 var (
@@ -36,40 +72,104 @@ var (
 	errUnexpectedFirstChar    = errors.New("unexpected first char found. Expecting 0-9")
 	errUnexpectedTrailingChar = errors.New("unexpected trailing char found. Expecting 0-9")
 	errTooLongInt             = errors.New("too long int")
+	errEmptyFloat             = errors.New("empty float")
+	errInvalidFloat           = errors.New("invalid float")
+	errFloatRange             = errors.New("float out of range")
 )
 
 func parseUintBuf(b []byte) (int, int, error) {
+	v, n, err := parseUint64Buf(b, math.MaxInt)
+	if err != nil {
+		return -1, n, err
+	}
+	return int(v), n, nil
+}
+
+// parseUint64Buf parses the leading digits of b. Parsing stops at the first
+// non digit and n reports the number of consumed bytes.
+func parseUint64Buf(b []byte, max uint64) (uint64, int, error) {
 	n := len(b)
 	if n == 0 {
-		return -1, 0, errEmptyInt
+		return 0, 0, errEmptyInt
 	}
-	v := 0
+	var v uint64
 	for i := 0; i < n; i++ {
-		c := b[i]
-		k := c - '0'
+		k := b[i] - '0'
 		if k > 9 {
 			if i == 0 {
-				return -1, i, errUnexpectedFirstChar
+				return 0, i, errUnexpectedFirstChar
 			}
 			return v, i, nil
 		}
-		// Test for overflow.
-		if v*10 < v {
-			return -1, i, errTooLongInt
+		// Test for overflow before multiplying.
+		if v > (max-uint64(k))/10 {
+			return 0, i, errTooLongInt
 		}
-		v = 10*v + int(k)
+		v = 10*v + uint64(k)
 	}
 	return v, n, nil
 }
 
+// parseIntBuf parses an optionally signed integer that fits into bitSize bits.
+func parseIntBuf(b []byte, bitSize uint) (int64, error) {
+	if len(b) == 0 {
+		return 0, errEmptyInt
+	}
+	neg := false
+	digits := b
+	switch b[0] {
+	case '-':
+		neg = true
+		digits = b[1:]
+	case '+':
+		digits = b[1:]
+	}
+	if len(digits) == 0 {
+		return 0, errEmptyInt
+	}
+
+	// the magnitude of the smallest negative value is one more than the largest positive value
+	max := uint64(1)<<(bitSize-1) - 1
+	if neg {
+		max++
+	}
+	v, n, err := parseUint64Buf(digits, max)
+	if err != nil {
+		return 0, err
+	}
+	if n != len(digits) {
+		return 0, errUnexpectedTrailingChar
+	}
+	if neg {
+		return -int64(v), nil
+	}
+	return int64(v), nil
+}
+
+// parseFloatBuf parses a float without copying b. strconv errors reference
+// the parsed string, which aliases b, so they are mapped to static errors.
+func parseFloatBuf(b []byte, bitSize int) (float64, error) {
+	if len(b) == 0 {
+		return 0, errEmptyFloat
+	}
+	f, err := strconv.ParseFloat(ToUnsafeString(b), bitSize)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errFloatRange
+		}
+		return 0, errInvalidFloat
+	}
+	return f, nil
+}
+
 // ToInt conversion from sql.RawBytes
 func ToInt(b []byte) int {
 	if b == nil {
 		return 0
 	}
-	i, err := strconv.Atoi(ToUnsafeString(b))
+	i, err := ParseInt(b)
 	if err != nil {
-		log.Error().Bytes("b", b).Msg("Atoi")
+		log.Error().Bytes("b", b).Msg("ToInt")
 		return 0
 	}
 	return i
@@ -97,7 +197,7 @@ func ToInt64(b []byte) int64 {
 	if b == nil {
 		return 0
 	}
-	i, err := strconv.ParseInt(ToUnsafeString(b), 10, 64)
+	i, err := ParseInt64(b)
 	if err != nil {
 		log.Error().Bytes("b", b).Msg("ToInt64")
 		return 0
@@ -110,7 +210,10 @@ func ToUInt(b []byte) uint {
 	if b == nil {
 		return 0
 	}
-	i, err := strconv.ParseUint(ToUnsafeString(b), 10, 32)
+	i, n, err := parseUint64Buf(b, math.MaxUint32)
+	if err == nil && n != len(b) {
+		err = errUnexpectedTrailingChar
+	}
 	if err != nil {
 		log.Error().Bytes("b", b).Msg("ToUInt")
 		return 0
@@ -123,7 +226,7 @@ func ToUInt64(b []byte) uint64 {
 	if b == nil {
 		return 0
 	}
-	i, err := strconv.ParseUint(ToUnsafeString(b), 10, 64)
+	i, err := ParseUint64(b)
 	if err != nil {
 		log.Error().Bytes("b", b).Msg("ToUInt64")
 		return 0
@@ -136,7 +239,7 @@ func ToFloat32(b []byte) float32 {
 	if b == nil {
 		return 0
 	}
-	f, err := strconv.ParseFloat(ToUnsafeString(b), 32)
+	f, err := parseFloatBuf(b, 32)
 	if err != nil {
 		log.Error().Bytes("b", b).Msg("ToFloat32")
 		return 0
@@ -149,7 +252,7 @@ func ToFloat64(b []byte) float64 {
 	if b == nil {
 		return 0
 	}
-	f, err := strconv.ParseFloat(ToUnsafeString(b), 64)
+	f, err := ParseFloat(b)
 	if err != nil {
 		log.Error().Bytes("b", b).Msg("ToFloat64")
 		return 0
@@ -159,34 +262,42 @@ func ToFloat64(b []byte) float64 {
 
 // ToTime conversion from sql.RawBytes
 func ToTime(b []byte) time.Time {
-	if b == nil {
+	t, err := parseTime(b)
+	if err != nil {
+		log.Error().Bytes("b", b).Msg("ToTime")
 		return time.Time{}
 	}
+	return t
+}
+
+// parseTime parses the time formats of ToTime. Zero dates are returned as zero time.
+func parseTime(b []byte) (time.Time, error) {
+	if b == nil {
+		return time.Time{}, nil
+	}
 	format := TimeFormat[:19]
 	s := ToUnsafeString(b)
 	if strings.Contains(s, "Z") {
 		format = time.RFC3339
+	} else if len(s) >= 19 && s[10] == ' ' {
+		// MySQL text protocol separates date and time by a blank
+		format = mysqlTimeFormat
 	}
 	switch len(s) {
 	case 8:
 		if s == "00:00:00" {
-			return time.Time{}
+			return time.Time{}, nil
 		}
 		format = format[11:19]
 	case 10:
 		if s == "0000-00-00" {
-			return time.Time{}
+			return time.Time{}, nil
 		}
 		format = format[:10]
 	case 19:
 		if s == "0000-00-00 00:00:00" {
-			return time.Time{}
+			return time.Time{}, nil
 		}
 	}
-	t, err := time.ParseInLocation(format, s, time.Local)
-	if err != nil {
-		log.Error().Bytes("b", b).Msg("ToTime")
-		return time.Time{}
-	}
-	return t
+	return time.ParseInLocation(format, s, time.Local)
 }
@@ -0,0 +1,255 @@
+package sdb_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func TestParseUint(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{name: "zero", in: "0", want: 0},
+		{name: "simple", in: "12345", want: 12345},
+		{name: "leading zeros", in: "007", want: 7},
+		{name: "max", in: strconv.Itoa(math.MaxInt), want: math.MaxInt},
+		{name: "overflow by one", in: "9223372036854775808", want: -1, wantErr: true},
+		{name: "overflow without wrap", in: "18446744073709551616", want: -1, wantErr: true},
+		{name: "empty", in: "", want: -1, wantErr: true},
+		{name: "sign", in: "-1", want: -1, wantErr: true},
+		{name: "trailing char", in: "12a", want: -1, wantErr: true},
+		{name: "decimal", in: "1.5", want: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sdb.ParseUint([]byte(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseUint(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseUint(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUint64(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    uint64
+		wantErr bool
+	}{
+		{name: "zero", in: "0", want: 0},
+		{name: "max", in: "18446744073709551615", want: math.MaxUint64},
+		{name: "overflow", in: "18446744073709551616", wantErr: true},
+		{name: "overflow long", in: "99999999999999999999999", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+		{name: "sign", in: "+1", wantErr: true},
+		{name: "trailing char", in: "1 ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sdb.ParseUint64([]byte(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseUint64(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseUint64(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{name: "zero", in: "0", want: 0},
+		{name: "negative zero", in: "-0", want: 0},
+		{name: "positive", in: "+42", want: 42},
+		{name: "negative", in: "-42", want: -42},
+		{name: "max", in: "9223372036854775807", want: math.MaxInt64},
+		{name: "min", in: "-9223372036854775808", want: math.MinInt64},
+		{name: "overflow", in: "9223372036854775808", wantErr: true},
+		{name: "underflow", in: "-9223372036854775809", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+		{name: "sign only", in: "-", wantErr: true},
+		{name: "double sign", in: "--1", wantErr: true},
+		{name: "trailing char", in: "-1x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sdb.ParseInt64([]byte(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseInt64(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseInt64(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	got, err := sdb.ParseInt([]byte("-1234"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != -1234 {
+		t.Errorf("ParseInt() = %v, want %v", got, -1234)
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{name: "integer", in: "12", want: 12},
+		{name: "decimal", in: "12.50", want: 12.5},
+		{name: "negative", in: "-0.004", want: -0.004},
+		{name: "exponent", in: "1e3", want: 1000},
+		{name: "empty", in: "", wantErr: true},
+		{name: "invalid", in: "1,5", wantErr: true},
+		{name: "range", in: "1e400", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sdb.ParseFloat([]byte(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFloat(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseFloat(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_NoAllocs(t *testing.T) {
+	b := []byte("-9223372036854775808")
+	u := []byte("18446744073709551615")
+	f := []byte("123.456")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = sdb.ParseInt64(b)
+		_, _ = sdb.ParseInt(b)
+		_, _ = sdb.ParseUint64(u)
+		_, _ = sdb.ParseUint(u[:5])
+		_, _ = sdb.ParseFloat(f)
+	})
+	if allocs != 0 {
+		t.Errorf("parsing allocated %v times, want 0", allocs)
+	}
+}
+
+func TestToConversions(t *testing.T) {
+	if got := sdb.ToInt([]byte("-17")); got != -17 {
+		t.Errorf("ToInt() = %v, want %v", got, -17)
+	}
+	if got := sdb.ToInt64([]byte("9223372036854775807")); got != math.MaxInt64 {
+		t.Errorf("ToInt64() = %v, want %v", got, int64(math.MaxInt64))
+	}
+	if got := sdb.ToUInt([]byte("4294967296")); got != 0 {
+		t.Errorf("ToUInt() = %v, want %v", got, 0)
+	}
+	if got := sdb.ToUInt64([]byte("18446744073709551615")); got != math.MaxUint64 {
+		t.Errorf("ToUInt64() = %v, want %v", got, uint64(math.MaxUint64))
+	}
+	if got := sdb.ToFloat32([]byte("1.25")); got != 1.25 {
+		t.Errorf("ToFloat32() = %v, want %v", got, 1.25)
+	}
+	if got := sdb.ToFloat64([]byte("x")); got != 0 {
+		t.Errorf("ToFloat64() = %v, want %v", got, 0)
+	}
+	if got := sdb.ToBool([]byte("1")); !got {
+		t.Errorf("ToBool() = %v, want %v", got, true)
+	}
+}
+
+func FuzzParseInt64(f *testing.F) {
+	for _, s := range []string{"0", "-1", "+1", "9223372036854775807", "-9223372036854775808", "9223372036854775808", "", "-", "1a"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := sdb.ParseInt64([]byte(s))
+		want, wantErr := strconv.ParseInt(s, 10, 64)
+		if (err != nil) != (wantErr != nil) {
+			t.Fatalf("ParseInt64(%q) error = %v, strconv error = %v", s, err, wantErr)
+		}
+		if err == nil && got != want {
+			t.Fatalf("ParseInt64(%q) = %v, strconv = %v", s, got, want)
+		}
+	})
+}
+
+func FuzzParseUint64(f *testing.F) {
+	for _, s := range []string{"0", "18446744073709551615", "18446744073709551616", "", "+1", "1a"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := sdb.ParseUint64([]byte(s))
+		want, wantErr := strconv.ParseUint(s, 10, 64)
+		if (err != nil) != (wantErr != nil) {
+			t.Fatalf("ParseUint64(%q) error = %v, strconv error = %v", s, err, wantErr)
+		}
+		if err == nil && got != want {
+			t.Fatalf("ParseUint64(%q) = %v, strconv = %v", s, got, want)
+		}
+	})
+}
+
+func FuzzParseFloat(f *testing.F) {
+	for _, s := range []string{"0", "-0.004", "1e308", "1e400", "NaN", "", "1,5"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := sdb.ParseFloat([]byte(s))
+		want, wantErr := strconv.ParseFloat(s, 64)
+		if (err != nil) != (wantErr != nil) {
+			t.Fatalf("ParseFloat(%q) error = %v, strconv error = %v", s, err, wantErr)
+		}
+		if err == nil && got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+			t.Fatalf("ParseFloat(%q) = %v, strconv = %v", s, got, want)
+		}
+	})
+}
+
+func TestToTime(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want time.Time
+	}{
+		{name: "mysql datetime", in: "2020-03-04 05:06:07", want: time.Date(2020, 3, 4, 5, 6, 7, 0, time.Local)},
+		{name: "mysql fraction", in: "2020-03-04 05:06:07.5", want: time.Date(2020, 3, 4, 5, 6, 7, 500000000, time.Local)},
+		{name: "iso datetime", in: "2020-03-04T05:06:07", want: time.Date(2020, 3, 4, 5, 6, 7, 0, time.Local)},
+		{name: "utc", in: "2020-03-04T05:06:07Z", want: time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{name: "date", in: "2020-03-04", want: time.Date(2020, 3, 4, 0, 0, 0, 0, time.Local)},
+		{name: "zero date", in: "0000-00-00", want: time.Time{}},
+		{name: "zero datetime", in: "0000-00-00 00:00:00", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sdb.ToTime([]byte(tt.in)); !got.Equal(tt.want) {
+				t.Errorf("ToTime(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
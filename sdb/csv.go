@@ -0,0 +1,365 @@
+package sdb
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seambiz/seambiz/stime"
+)
+
+// CSVOptions configures ExportCSV
+type CSVOptions struct {
+	// Comma field delimiter, default ','
+	Comma rune
+	// NoHeader omits the header row with the column names
+	NoHeader bool
+	// DecimalComma writes DECIMAL and FLOAT values with german decimal comma
+	DecimalComma bool
+	// GermanDates writes DATE and DATETIME values in stime.DateLayout and stime.DateTimeLayout
+	GermanDates bool
+	// Null is written for NULL values, default empty
+	Null string
+}
+
+// ExportCSV writes rows as CSV to w and returns the number of exported rows.
+// nil opts writes comma separated values with header row. The caller still owns and closes rows.
+func ExportCSV(w io.Writer, rows *sql.Rows, opts *CSVOptions) (int, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	kinds := make([]columnKind, len(types))
+	record := make([]string, len(types))
+	for i, ct := range types {
+		kinds[i] = columnKindOf(ct)
+		record[i] = ct.Name()
+	}
+
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	if !opts.NoHeader {
+		if err := cw.Write(record); err != nil {
+			return 0, err
+		}
+	}
+
+	n := 0
+	err = scanRawRows(rows, func(values []sql.RawBytes) error {
+		for i, v := range values {
+			record[i] = csvValue(kinds[i], v, opts)
+		}
+		n++
+		return cw.Write(record)
+	})
+	cw.Flush()
+	if err == nil {
+		err = cw.Error()
+	}
+	return n, err
+}
+
+// ExportTSV writes rows as tab separated values, see ExportCSV.
+func ExportTSV(w io.Writer, rows *sql.Rows, opts *CSVOptions) (int, error) {
+	o := CSVOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.Comma = '\t'
+	return ExportCSV(w, rows, &o)
+}
+
+func csvValue(kind columnKind, b []byte, opts *CSVOptions) string {
+	if b == nil {
+		return opts.Null
+	}
+
+	switch kind {
+	case columnDecimal, columnFloat:
+		if opts.DecimalComma {
+			return strings.Replace(string(b), ".", ",", 1)
+		}
+	case columnDateTime, columnDate:
+		if opts.GermanDates {
+			t := ToTime(b)
+			if t.IsZero() {
+				return opts.Null
+			}
+			if kind == columnDate {
+				return t.Format(stime.DateLayout)
+			}
+			return t.Format(stime.DateTimeLayout)
+		}
+	case columnBinary:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+	return string(b)
+}
+
+// CSVType declares how a CSV value is parsed before importing
+type CSVType int
+
+const (
+	// CSVString imports the value as is
+	CSVString CSVType = iota
+	// CSVInt expects an integer
+	CSVInt
+	// CSVDecimal expects a decimal number with point or, with DecimalComma, comma and
+	// optional points between groups of three digits. Exponents, NaN and Inf are rejected.
+	CSVDecimal
+	// CSVBool accepts the values of strconv.ParseBool and is imported as 0 or 1
+	CSVBool
+	// CSVDate accepts stime.DateLayout or ISO dates
+	CSVDate
+	// CSVDateTime accepts stime.DateTimeLayout or MySQL datetime
+	CSVDateTime
+)
+
+// CSVColumn maps a CSV header to a database column
+type CSVColumn struct {
+	Header string
+	Column string
+	Type   CSVType
+}
+
+// CSVImport configures ImportCSV
+type CSVImport struct {
+	Table string
+	// Columns to import, nil imports all CSV columns as strings into columns named like the header.
+	// Such headers must be plain identifiers of ASCII letters, digits and underscores.
+	Columns []CSVColumn
+	// Comma field delimiter, default ','
+	Comma rune
+	// DecimalComma parses CSVDecimal values with german decimal comma
+	DecimalComma bool
+	// EmptyAsNull imports empty CSVString values as NULL, empty values of other types are always NULL
+	EmptyAsNull bool
+	// BatchSize records per statement, default 1000
+	BatchSize int
+	// OnDuplicateKeyUpdate see UpsertStatement.OnDuplicateKeyUpdate
+	OnDuplicateKeyUpdate []string
+}
+
+// CSVLineError reports an invalid CSV line. The line is skipped.
+type CSVLineError struct {
+	Line   int
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *CSVLineError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: column %s: invalid value %q: %v", e.Line, e.Column, e.Value, e.Err)
+}
+
+func (e *CSVLineError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	errCSVMissingHeader  = errors.New("csv: missing header")
+	errCSVInvalidHeader  = errors.New("csv: header is no valid column name")
+	errCSVInvalidBool    = errors.New("invalid boolean")
+	errCSVInvalidDecimal = errors.New("invalid decimal")
+	errCSVInvalidDate    = errors.New("invalid date")
+)
+
+// nolint[gochecknoblobals]
+var (
+	csvIdentifier   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	csvDecimal      = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+	csvDecimalComma = regexp.MustCompile(`^[+-]?(\d{1,3}(\.\d{3})+|\d*)(,\d+)?$`)
+)
+
+// ImportCSV reads a CSV with header row from r and passes batches of upsert
+// statements to exec, which typically runs db.Exec(u.Query()).
+//
+// Lines with invalid values are skipped and returned as line errors. The
+// returned error is set, if reading r fails, a mapped header is missing, a header
+// imported without Columns is no plain identifier or exec fails.
+func ImportCSV(r io.Reader, opts CSVImport, exec func(u *UpsertStatement) error) ([]*CSVLineError, error) {
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.ReuseRecord = true
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		if i == 0 {
+			h = strings.TrimPrefix(h, "\ufeff")
+		}
+		index[strings.TrimSpace(h)] = i
+	}
+
+	columns := opts.Columns
+	if columns == nil {
+		for _, h := range header {
+			h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+			if !csvIdentifier.MatchString(h) {
+				return nil, fmt.Errorf("%w %q", errCSVInvalidHeader, h)
+			}
+			columns = append(columns, CSVColumn{Header: h, Column: h})
+		}
+	}
+	fields := make([]int, len(columns))
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		pos, ok := index[c.Header]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", errCSVMissingHeader, c.Header)
+		}
+		fields[i] = pos
+		names[i] = c.Column
+		if names[i] == "" {
+			names[i] = c.Header
+		}
+	}
+
+	var (
+		lineErrs []*CSVLineError
+		u        *UpsertStatement
+		n        int
+	)
+	values := make([]interface{}, len(columns))
+	flush := func() error {
+		if u == nil {
+			return nil
+		}
+		err := exec(u)
+		u = nil
+		n = 0
+		return err
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				lineErrs = append(lineErrs, &CSVLineError{Line: perr.Line, Err: perr.Err})
+				continue
+			}
+			return lineErrs, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		var lineErr *CSVLineError
+		for i, c := range columns {
+			raw := record[fields[i]]
+			v, err := parseCSVValue(c.Type, raw, opts)
+			if err != nil {
+				lineErr = &CSVLineError{Line: line, Column: c.Header, Value: raw, Err: err}
+				break
+			}
+			values[i] = v
+		}
+		if lineErr != nil {
+			lineErrs = append(lineErrs, lineErr)
+			continue
+		}
+
+		if u == nil {
+			u = &UpsertStatement{}
+			u.InsertInto(opts.Table)
+			u.Columns(names...)
+			u.OnDuplicateKeyUpdate(opts.OnDuplicateKeyUpdate)
+		}
+		u.RecordValues(values...)
+		n++
+		if n >= batchSize {
+			if err := flush(); err != nil {
+				return lineErrs, err
+			}
+		}
+	}
+	return lineErrs, flush()
+}
+
+// parseCSVValue validates and normalizes s for MySQL. nil means NULL.
+func parseCSVValue(typ CSVType, s string, opts CSVImport) (interface{}, error) {
+	if typ != CSVString {
+		s = strings.TrimSpace(s)
+	}
+	if s == "" && (opts.EmptyAsNull || typ != CSVString) {
+		return nil, nil
+	}
+
+	switch typ {
+	case CSVInt:
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			if _, uerr := strconv.ParseUint(s, 10, 64); uerr != nil {
+				return nil, err
+			}
+		}
+	case CSVDecimal:
+		if !opts.DecimalComma {
+			if !csvDecimal.MatchString(s) {
+				return nil, errCSVInvalidDecimal
+			}
+			break
+		}
+		if s == "+" || s == "-" || !csvDecimalComma.MatchString(s) {
+			return nil, errCSVInvalidDecimal
+		}
+		s = strings.Replace(strings.ReplaceAll(s, ".", ""), ",", ".", 1)
+	case CSVBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errCSVInvalidBool
+		}
+		if b {
+			return "1", nil
+		}
+		return "0", nil
+	case CSVDate:
+		t, err := parseCSVTime(s, stime.DateLayout, "2006-01-02")
+		if err != nil {
+			return nil, err
+		}
+		return t.Format("2006-01-02"), nil
+	case CSVDateTime:
+		t, err := parseCSVTime(s, stime.DateTimeLayout, mysqlTimeFormat, stime.DateLayout, "2006-01-02")
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(mysqlTimeFormat), nil
+	}
+	return s, nil
+}
+
+func parseCSVTime(s string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errCSVInvalidDate
+}
@@ -0,0 +1,234 @@
+package sdb_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func TestExportCSV(t *testing.T) {
+	res := &fakeResult{
+		columns: []string{"id", "name", "price", "created", "day"},
+		types:   []string{"INT", "VARCHAR", "DECIMAL", "DATETIME", "DATE"},
+		rows: [][]driver.Value{
+			{[]byte("1"), []byte("Müller; \"Hans\""), []byte("12.50"), []byte("2020-03-04 05:06:07"), []byte("2020-03-04")},
+			{[]byte("2"), nil, []byte("-0.5"), nil, []byte("0000-00-00")},
+		},
+	}
+	tests := []struct {
+		name   string
+		export func(w *bytes.Buffer, db *fakeResult) (int, error)
+		want   string
+	}{
+		{
+			name: "default",
+			export: func(w *bytes.Buffer, res *fakeResult) (int, error) {
+				rows, err := openFakeDB(t, res).Query("SELECT")
+				if err != nil {
+					return 0, err
+				}
+				defer rows.Close()
+				return sdb.ExportCSV(w, rows, nil)
+			},
+			want: "id,name,price,created,day\n" +
+				"1,\"Müller; \"\"Hans\"\"\",12.50,2020-03-04 05:06:07,2020-03-04\n" +
+				"2,,-0.5,,0000-00-00\n",
+		},
+		{
+			name: "german",
+			export: func(w *bytes.Buffer, res *fakeResult) (int, error) {
+				rows, err := openFakeDB(t, res).Query("SELECT")
+				if err != nil {
+					return 0, err
+				}
+				defer rows.Close()
+				return sdb.ExportCSV(w, rows, &sdb.CSVOptions{Comma: ';', NoHeader: true, DecimalComma: true, GermanDates: true, Null: "NULL"})
+			},
+			want: "1;\"Müller; \"\"Hans\"\"\";12,50;04.03.2020 05:06:07;04.03.2020\n" +
+				"2;NULL;-0,5;NULL;NULL\n",
+		},
+		{
+			name: "tsv",
+			export: func(w *bytes.Buffer, res *fakeResult) (int, error) {
+				rows, err := openFakeDB(t, res).Query("SELECT")
+				if err != nil {
+					return 0, err
+				}
+				defer rows.Close()
+				return sdb.ExportTSV(w, rows, nil)
+			},
+			want: "id\tname\tprice\tcreated\tday\n" +
+				"1\t\"Müller; \"\"Hans\"\"\"\t12.50\t2020-03-04 05:06:07\t2020-03-04\n" +
+				"2\t\t-0.5\t\t0000-00-00\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			n, err := tt.export(&out, res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != 2 {
+				t.Errorf("exported %d rows, want %d", n, 2)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	in := "\ufeffNr;Name;Preis;Aktiv;Datum\n" +
+		"1;O'Brien;1.234,50;true;04.03.2020\n" +
+		"x;Invalid;1,00;true;04.03.2020\n" +
+		"3; Spaces ;;false;2020-03-05\n" +
+		"4;Bad date;2,00;1;32.13.2020\n" +
+		"5;Short\n" +
+		"6;Last;0,99;0;\n"
+
+	var queries []string
+	lineErrs, err := sdb.ImportCSV(strings.NewReader(in), sdb.CSVImport{
+		Table: "article",
+		Columns: []sdb.CSVColumn{
+			{Header: "Nr", Column: "id", Type: sdb.CSVInt},
+			{Header: "Name", Column: "name"},
+			{Header: "Preis", Column: "price", Type: sdb.CSVDecimal},
+			{Header: "Aktiv", Column: "active", Type: sdb.CSVBool},
+			{Header: "Datum", Column: "day", Type: sdb.CSVDate},
+		},
+		Comma:                ';',
+		DecimalComma:         true,
+		BatchSize:            2,
+		OnDuplicateKeyUpdate: []string{"name=VALUES(name)"},
+	}, func(u *sdb.UpsertStatement) error {
+		queries = append(queries, u.Query())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"INSERT INTO article ( `id` , `name` , `price` , `active` , `day` ) VALUES  ( '1' , 'O\\'Brien' , '1234.50' , '1' , '2020-03-04' ), ( '3' , ' Spaces ' , NULL , '0' , '2020-03-05' ) ON DUPLICATE KEY UPDATE name=VALUES(name) ",
+		"INSERT INTO article ( `id` , `name` , `price` , `active` , `day` ) VALUES  ( '6' , 'Last' , '0.99' , '0' , NULL ) ON DUPLICATE KEY UPDATE name=VALUES(name) ",
+	}
+	if len(queries) != len(want) {
+		t.Fatalf("got %d statements, want %d: %q", len(queries), len(want), queries)
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Errorf("statement %d\ngot:  %s\nwant: %s", i, queries[i], want[i])
+		}
+	}
+
+	wantLines := []int{3, 5, 6}
+	if len(lineErrs) != len(wantLines) {
+		t.Fatalf("got %d line errors, want %d: %v", len(lineErrs), len(wantLines), lineErrs)
+	}
+	for i, line := range wantLines {
+		if lineErrs[i].Line != line {
+			t.Errorf("line error %d reported line %d, want %d: %v", i, lineErrs[i].Line, line, lineErrs[i])
+		}
+	}
+	if lineErrs[0].Column != "Nr" || lineErrs[0].Value != "x" {
+		t.Errorf("unexpected line error %v", lineErrs[0])
+	}
+}
+
+func TestImportCSVErr(t *testing.T) {
+	_, err := sdb.ImportCSV(strings.NewReader("a,b\n1,2\n"), sdb.CSVImport{
+		Table:   "t",
+		Columns: []sdb.CSVColumn{{Header: "c"}},
+	}, func(u *sdb.UpsertStatement) error { return nil })
+	if err == nil {
+		t.Error("expected error for missing header")
+	}
+
+	errExec := errors.New("exec")
+	_, err = sdb.ImportCSV(strings.NewReader("a,b\n1,2\n"), sdb.CSVImport{Table: "t"}, func(u *sdb.UpsertStatement) error {
+		_ = u.Query()
+		return errExec
+	})
+	if !errors.Is(err, errExec) {
+		t.Errorf("got error %v, want %v", err, errExec)
+	}
+}
+
+func TestImportCSVHostileHeader(t *testing.T) {
+	in := "id,\"x`) VALUES ('1','2'); DROP TABLE users; -- \"\n1,2\n"
+	called := false
+	_, err := sdb.ImportCSV(strings.NewReader(in), sdb.CSVImport{Table: "t"}, func(u *sdb.UpsertStatement) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("got error %v, exec called %v, want rejected header", err, called)
+	}
+
+	var query string
+	_, err = sdb.ImportCSV(strings.NewReader("a\n1\n"), sdb.CSVImport{
+		Table:   "t",
+		Columns: []sdb.CSVColumn{{Header: "a", Column: "a`b"}},
+	}, func(u *sdb.UpsertStatement) error {
+		query = u.Query()
+		return nil
+	})
+	if want := "INSERT INTO t ( `a``b` ) VALUES  ( '1' ) "; err != nil || query != want {
+		t.Errorf("got %q, %v, want %q", query, err, want)
+	}
+}
+
+func TestImportCSVDecimal(t *testing.T) {
+	tests := []struct {
+		in           string
+		decimalComma bool
+		want         string
+	}{
+		{in: "1234.50", want: "1234.50"},
+		{in: "-.5", want: "-.5"},
+		{in: "1,5"},
+		{in: "NaN"},
+		{in: "Inf"},
+		{in: "0x1p-2"},
+		{in: "1e3"},
+		{in: "1.234.567,89", decimalComma: true, want: "1234567.89"},
+		{in: "1234,5", decimalComma: true, want: "1234.5"},
+		{in: "-0,99", decimalComma: true, want: "-0.99"},
+		{in: "1.5", decimalComma: true},
+		{in: "12.34,5", decimalComma: true},
+		{in: "1.234.5", decimalComma: true},
+		{in: "-", decimalComma: true},
+		{in: "NaN", decimalComma: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var query string
+			lineErrs, err := sdb.ImportCSV(strings.NewReader("n\n\""+tt.in+"\"\n"), sdb.CSVImport{
+				Table:        "t",
+				Columns:      []sdb.CSVColumn{{Header: "n", Type: sdb.CSVDecimal}},
+				DecimalComma: tt.decimalComma,
+			}, func(u *sdb.UpsertStatement) error {
+				query = u.Query()
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if len(lineErrs) != 1 || lineErrs[0].Value != tt.in || query != "" {
+					t.Errorf("got %q, %v, want line error", query, lineErrs)
+				}
+				return
+			}
+			if want := "INSERT INTO t ( `n` ) VALUES  ( '" + tt.want + "' ) "; len(lineErrs) != 0 || query != want {
+				t.Errorf("got %q, %v, want %q", query, lineErrs, want)
+			}
+		})
+	}
+}
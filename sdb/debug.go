@@ -0,0 +1,139 @@
+package sdb
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// DebugEnv environment variable enabling the pool debug mode, if set to a non-empty value.
+// Building with the tag sdbdebug enables it as well.
+const DebugEnv = "SDB_DEBUG"
+
+// nolint[gochecknoblobals]
+var (
+	poolDebugOn int32
+
+	liveMu  sync.Mutex
+	liveSeq uint64
+	live    = map[*SQLStatement]liveStatement{}
+
+	releasedMu   sync.Mutex
+	releasedJSON = map[*JsonBuffer][]byte{}
+)
+
+func init() {
+	if debugBuild || os.Getenv(DebugEnv) != "" {
+		poolDebugOn = 1
+	}
+}
+
+// liveStatement a statement taken from the pool in debug mode
+type liveStatement struct {
+	seq   uint64
+	stack []byte
+}
+
+// EnablePoolDebug switches the pool debug mode on or off.
+//
+// In debug mode released statements are not returned to the pool, but
+// marked, and any further use panics with the stack of the releasing call.
+// Released JsonBuffers are kept out of the pool as well, and releasing one
+// again by Release, Bytes or WriteTo panics.
+// Statements are tracked from NewSQLStatement to Release for CheckSQLStatementLeaks.
+// Statements never released are kept in memory, so this is meant for tests and development only.
+func EnablePoolDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&poolDebugOn, v)
+}
+
+func poolDebugEnabled() bool {
+	return atomic.LoadInt32(&poolDebugOn) == 1
+}
+
+func trackStatement(s *SQLStatement) {
+	liveMu.Lock()
+	liveSeq++
+	live[s] = liveStatement{seq: liveSeq, stack: debug.Stack()}
+	liveMu.Unlock()
+}
+
+// releaseStatement marks s as released instead of returning it to the pool
+func releaseStatement(s *SQLStatement) {
+	s.released = debug.Stack()
+	liveMu.Lock()
+	delete(live, s)
+	liveMu.Unlock()
+}
+
+// checkReleased panics, if s was released in debug mode
+func (s *SQLStatement) checkReleased() {
+	if s.released != nil {
+		panic(fmt.Sprintf("sdb: SQLStatement used after release\n\nreleased by:\n%s", s.released))
+	}
+}
+
+// releaseJSONBuffer marks t as released instead of returning it to the pool
+func releaseJSONBuffer(t *JsonBuffer) {
+	t.checkReleased()
+	bb(t).B = nil
+	releasedMu.Lock()
+	releasedJSON[t] = debug.Stack()
+	releasedMu.Unlock()
+}
+
+// checkReleased panics, if t was released in debug mode
+func (t *JsonBuffer) checkReleased() {
+	releasedMu.Lock()
+	stack := releasedJSON[t]
+	releasedMu.Unlock()
+	if stack != nil {
+		panic(fmt.Sprintf("sdb: JsonBuffer used after release\n\nreleased by:\n%s", stack))
+	}
+}
+
+// LeakReporter is implemented by *testing.T and *testing.B
+type LeakReporter interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
+
+// CheckSQLStatementLeaks enables the pool debug mode for the rest of the test and
+// reports an error for every statement created meanwhile, that was not released by
+// Release, Query or Bytes when the test ends. Not meant for parallel tests.
+func CheckSQLStatementLeaks(t LeakReporter) {
+	t.Helper()
+
+	wasEnabled := poolDebugEnabled()
+	EnablePoolDebug(true)
+	liveMu.Lock()
+	start := liveSeq
+	liveMu.Unlock()
+
+	t.Cleanup(func() {
+		t.Helper()
+
+		EnablePoolDebug(wasEnabled)
+		liveMu.Lock()
+		var leaked []liveStatement
+		for s, l := range live {
+			if l.seq > start {
+				leaked = append(leaked, l)
+				delete(live, s)
+			}
+		}
+		liveMu.Unlock()
+
+		sort.Slice(leaked, func(i, j int) bool { return leaked[i].seq < leaked[j].seq })
+		for _, l := range leaked {
+			t.Errorf("sdb: SQLStatement not released, created by:\n%s", l.stack)
+		}
+	})
+}
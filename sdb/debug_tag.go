@@ -0,0 +1,6 @@
+//go:build sdbdebug
+// +build sdbdebug
+
+package sdb
+
+const debugBuild = true
@@ -0,0 +1,184 @@
+package sdb_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func enablePoolDebug(t *testing.T) {
+	t.Helper()
+
+	sdb.EnablePoolDebug(true)
+	t.Cleanup(func() {
+		sdb.EnablePoolDebug(false)
+	})
+}
+
+// expectReleasePanic fails the test, if fn does not panic with the stack of releasedBy
+func expectReleasePanic(t *testing.T, releasedBy string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		t.Helper()
+
+		r := recover()
+		if r == nil {
+			t.Fatal("expected panic on use after release")
+		}
+		msg := fmt.Sprint(r)
+		if !strings.Contains(msg, "used after release") || !strings.Contains(msg, releasedBy) {
+			t.Errorf("unexpected panic message:\n%s", msg)
+		}
+	}()
+	fn()
+}
+
+func releaseStatement(sql *sdb.SQLStatement) string {
+	return sql.Query()
+}
+
+func TestPoolDebug_UseAfterRelease(t *testing.T) {
+	enablePoolDebug(t)
+
+	tests := []struct {
+		name string
+		use  func(sql *sdb.SQLStatement)
+	}{
+		{name: "String", use: func(sql *sdb.SQLStatement) { _ = sql.String() }},
+		{name: "Query", use: func(sql *sdb.SQLStatement) { _ = sql.Query() }},
+		{name: "Bytes", use: func(sql *sdb.SQLStatement) { _ = sql.Bytes() }},
+		{name: "Append", use: func(sql *sdb.SQLStatement) { sql.Append("WHERE") }},
+		{name: "AppendInt", use: func(sql *sdb.SQLStatement) { sql.AppendInt(1) }},
+		{name: "Fields", use: func(sql *sdb.SQLStatement) { sql.Fields("", []string{"id"}) }},
+		{name: "Release", use: func(sql *sdb.SQLStatement) { sql.Release() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := sdb.NewSQLStatement()
+			sql.Append("SELECT 1")
+			if got := releaseStatement(sql); got != "SELECT 1 " {
+				t.Errorf("got '%s', want '%s'", got, "SELECT 1 ")
+			}
+			expectReleasePanic(t, "sdb_test.releaseStatement", func() { tt.use(sql) })
+		})
+	}
+}
+
+func TestPoolDebug_UpsertStringAfterQuery(t *testing.T) {
+	enablePoolDebug(t)
+
+	u := sdb.UpsertStatement{}
+	u.InsertInto("t")
+	u.Columns("id")
+	u.RecordValues(1)
+	_ = u.Query()
+
+	expectReleasePanic(t, "UpsertStatement).Query", func() { _ = u.String() })
+}
+
+func releaseJsonBuffer(buf *sdb.JsonBuffer) []byte {
+	return buf.Bytes()
+}
+
+func TestPoolDebug_JsonBufferDoubleRelease(t *testing.T) {
+	enablePoolDebug(t)
+
+	tests := []struct {
+		name string
+		use  func(buf *sdb.JsonBuffer)
+	}{
+		{name: "Release", use: func(buf *sdb.JsonBuffer) { buf.Release() }},
+		{name: "Bytes", use: func(buf *sdb.JsonBuffer) { _ = buf.Bytes() }},
+		{name: "WriteTo", use: func(buf *sdb.JsonBuffer) { _, _ = buf.WriteTo(io.Discard) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			buf.S("{}")
+			if got := string(releaseJsonBuffer(buf)); got != "{}" {
+				t.Errorf("got '%s', want '%s'", got, "{}")
+			}
+			expectReleasePanic(t, "sdb_test.releaseJsonBuffer", func() { tt.use(buf) })
+		})
+	}
+}
+
+func TestPoolDebug_Disabled(t *testing.T) {
+	sql := sdb.NewSQLStatement()
+	sql.Release()
+
+	next := sdb.NewSQLStatement()
+	next.Append("SELECT")
+	if got := next.Query(); got != "SELECT " {
+		t.Errorf("got '%s', want '%s'", got, "SELECT ")
+	}
+}
+
+// leakReporter records the errors of CheckSQLStatementLeaks
+type leakReporter struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (r *leakReporter) Helper() {}
+
+func (r *leakReporter) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *leakReporter) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *leakReporter) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func leakStatement() *sdb.SQLStatement {
+	sql := sdb.NewSQLStatement()
+	sql.Append("SELECT")
+	return sql
+}
+
+func TestCheckSQLStatementLeaks(t *testing.T) {
+	leaked := leakStatement()
+	defer leaked.Release()
+
+	r := &leakReporter{}
+	sdb.CheckSQLStatementLeaks(r)
+
+	released := sdb.NewSQLStatement()
+	_ = released.Query()
+	_ = sdb.NewSQLStatement().Bytes()
+	leakedDuringTest := leakStatement()
+
+	r.finish()
+
+	if len(r.errors) != 1 {
+		t.Fatalf("got %d leak errors, want %d: %v", len(r.errors), 1, r.errors)
+	}
+	if !strings.Contains(r.errors[0], "sdb_test.leakStatement") {
+		t.Errorf("leak error misses creating stack:\n%s", r.errors[0])
+	}
+	leakedDuringTest.Release()
+}
+
+func TestCheckSQLStatementLeaks_NoLeaks(t *testing.T) {
+	sdb.CheckSQLStatementLeaks(t)
+
+	sql := sdb.NewSQLStatement()
+	sql.Append("SELECT")
+	_ = sql.Query()
+
+	u := sdb.UpsertStatement{}
+	u.InsertInto("t")
+	u.Columns("id")
+	u.RecordValues(1)
+	_ = u.Query()
+}
@@ -0,0 +1,245 @@
+package sdb
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type columnKind int
+
+const (
+	columnString columnKind = iota
+	columnInt
+	columnDecimal
+	columnFloat
+	columnBool
+	columnDateTime
+	columnDate
+	columnJSON
+	columnBinary
+)
+
+// columnKindOf maps the database type name reported by the driver to the encoding of the column.
+func columnKindOf(ct *sql.ColumnType) columnKind {
+	name := strings.ToUpper(ct.DatabaseTypeName())
+	name = strings.TrimPrefix(name, "UNSIGNED ")
+
+	switch name {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "INT2", "INT4", "INT8", "YEAR":
+		return columnInt
+	case "DECIMAL", "NUMERIC":
+		return columnDecimal
+	case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+		return columnFloat
+	case "BOOL", "BOOLEAN":
+		return columnBool
+	case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return columnDateTime
+	case "DATE":
+		return columnDate
+	case "JSON", "JSONB":
+		return columnJSON
+	case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "BYTEA", "BIT", "GEOMETRY":
+		return columnBinary
+	}
+	return columnString
+}
+
+// scanRawRows calls fn for every row with the raw column values.
+// The values are only valid until fn returns.
+func scanRawRows(rows *sql.Rows, fn func(values []sql.RawBytes) error) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]sql.RawBytes, len(cols))
+	dest := make([]interface{}, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := fn(values); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// NDJSONOptions configures ExportNDJSON
+type NDJSONOptions struct {
+	// Time encoding of DATETIME and TIMESTAMP columns, default TimeRFC3339Nano.
+	// DATE columns are written as ISO date, if Time is TimeRFC3339Nano.
+	Time TimeEncoding
+	// Threshold in bytes after which the output is flushed, see JsonStream.
+	Threshold int
+}
+
+// ExportNDJSON writes rows as newline delimited JSON (JSON Lines) to w, one
+// object per row with the column names as keys. The JSON type of a value is
+// chosen by the column type, NULL is written as null. Memory usage is
+// constant, because rows are read as sql.RawBytes and streamed.
+//
+// nil opts writes times as RFC 3339. The caller still owns and closes rows.
+// It returns the number of exported rows.
+func ExportNDJSON(w io.Writer, rows *sql.Rows, opts *NDJSONOptions) (int, error) {
+	if opts == nil {
+		opts = &NDJSONOptions{}
+	}
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	kinds := make([]columnKind, len(types))
+	for i, ct := range types {
+		kinds[i] = columnKindOf(ct)
+	}
+
+	// render the keys once
+	keys := make([]string, len(types))
+	kb := NewJsonBuffer()
+	for i, ct := range types {
+		kb.Reset()
+		if i > 0 {
+			kb.S(",")
+		}
+		kb.JSe(ct.Name())
+		kb.S(":")
+		keys[i] = string(bb(kb).B)
+	}
+	kb.Release()
+
+	s := NewJsonStream(w, opts.Threshold)
+	n := 0
+	err = scanRawRows(rows, func(values []sql.RawBytes) error {
+		s.S("{")
+		for i, v := range values {
+			s.S(keys[i])
+			s.rawValue(kinds[i], v, opts.Time)
+		}
+		s.S("}")
+		n++
+		return s.NewLine()
+	})
+	if cerr := s.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// rawValue writes the raw column value b as JSON value according to its column kind.
+func (t *JsonBuffer) rawValue(kind columnKind, b []byte, enc TimeEncoding) {
+	if b == nil {
+		t.S("null")
+		return
+	}
+
+	switch kind {
+	case columnInt, columnDecimal:
+		// keep the exact representation, e.g. of DECIMAL or BIGINT UNSIGNED
+		if isJSONNumber(b) {
+			bb(t).Write(b)
+			return
+		}
+	case columnFloat:
+		if f, err := ParseFloat(b); err == nil {
+			t.F64(f)
+			return
+		}
+	case columnBool:
+		if v, err := strconv.ParseBool(ToUnsafeString(b)); err == nil {
+			if v {
+				t.S("true")
+			} else {
+				t.S("false")
+			}
+			return
+		}
+	case columnDateTime:
+		// unparsable values are written as string like other kinds
+		if v, err := parseTime(b); err == nil {
+			t.TE(v, enc)
+			return
+		}
+	case columnDate:
+		if enc == TimeRFC3339Nano {
+			enc = TimeDateISO
+		}
+		if v, err := parseTime(b); err == nil {
+			t.TE(v, enc)
+			return
+		}
+	case columnJSON:
+		if json.Valid(b) {
+			bb(t).Write(b)
+			return
+		}
+	case columnBinary:
+		n := base64.StdEncoding.EncodedLen(len(b))
+		bb(t).Write(JSONQuote)
+		buf := bb(t).B
+		l := len(buf)
+		if cap(buf)-l < n {
+			buf = append(buf, make([]byte, n)...)[:l]
+		}
+		buf = buf[:l+n]
+		base64.StdEncoding.Encode(buf[l:], b)
+		bb(t).B = buf
+		bb(t).Write(JSONQuote)
+		return
+	}
+	t.JSe(ToUnsafeString(b))
+}
+
+// isJSONNumber reports whether b is a number according to the JSON grammar.
+func isJSONNumber(b []byte) bool {
+	i := 0
+	if i < len(b) && b[i] == '-' {
+		i++
+	}
+	if i >= len(b) {
+		return false
+	}
+	switch {
+	case b[i] == '0':
+		i++
+	case b[i] >= '1' && b[i] <= '9':
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+	default:
+		return false
+	}
+	if i < len(b) && b[i] == '.' {
+		i++
+		start := i
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		i++
+		if i < len(b) && (b[i] == '+' || b[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(b)
+}
@@ -0,0 +1,115 @@
+package sdb_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func exportResult() *fakeResult {
+	return &fakeResult{
+		columns: []string{"id", "price", "ratio", "active", "name", "created", "day", "doc", "data", "note"},
+		types:   []string{"UNSIGNED BIGINT", "DECIMAL", "DOUBLE", "BOOL", "VARCHAR", "DATETIME", "DATE", "JSON", "BLOB", "TEXT"},
+		rows: [][]driver.Value{
+			{
+				[]byte("18446744073709551615"), []byte("12.50"), []byte("0.25"), []byte("1"), []byte(`a "quoted" name`),
+				[]byte("2020-03-04 05:06:07"), []byte("2020-03-04"), []byte(`{"a":[1,2]}`), []byte{0, 1, 2}, []byte(""),
+			},
+			{
+				[]byte("7"), []byte("-0.5"), nil, []byte("0"), []byte("äöü"),
+				[]byte("0000-00-00 00:00:00"), nil, []byte("not json"), nil, nil,
+			},
+		},
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	db := openFakeDB(t, exportResult())
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var out bytes.Buffer
+	n, err := sdb.ExportNDJSON(&out, rows, &sdb.NDJSONOptions{Time: sdb.TimeDateISO, Threshold: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("exported %d rows, want %d", n, 2)
+	}
+
+	want := strings.Join([]string{
+		`{"id":18446744073709551615,"price":12.50,"ratio":0.25,"active":true,"name":"a \"quoted\" name","created":"2020-03-04","day":"2020-03-04","doc":{"a":[1,2]},"data":"AAEC","note":""}`,
+		`{"id":7,"price":-0.5,"ratio":null,"active":false,"name":"äöü","created":null,"day":null,"doc":"not json","data":null,"note":null}`,
+		"",
+	}, "\n")
+	if got := out.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExportNDJSON_DefaultOptions(t *testing.T) {
+	res := &fakeResult{
+		columns: []string{"day", "ts"},
+		types:   []string{"DATE", "TIMESTAMP"},
+		rows:    [][]driver.Value{{[]byte("2020-03-04"), []byte("2020-03-04T05:06:07Z")}},
+	}
+	// options without Time encode like nil options
+	for _, opts := range []*sdb.NDJSONOptions{nil, {Threshold: 16}} {
+		db := openFakeDB(t, res)
+		rows, err := db.Query("SELECT")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+
+		var out bytes.Buffer
+		if _, err := sdb.ExportNDJSON(&out, rows, opts); err != nil {
+			t.Fatal(err)
+		}
+		want := `{"day":"2020-03-04","ts":"2020-03-04T05:06:07Z"}` + "\n"
+		if got := out.String(); got != want {
+			t.Errorf("opts %+v: got '%s', want '%s'", opts, got, want)
+		}
+	}
+}
+
+func TestExportNDJSON_WriteError(t *testing.T) {
+	db := openFakeDB(t, exportResult())
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if _, err := sdb.ExportNDJSON(failingWriter{}, rows, &sdb.NDJSONOptions{Threshold: 1}); err == nil {
+		t.Error("expected write error")
+	}
+}
+
+func TestExportNDJSON_UnparsableTime(t *testing.T) {
+	db := openFakeDB(t, &fakeResult{
+		columns: []string{"created", "day"},
+		types:   []string{"DATETIME", "DATE"},
+		rows:    [][]driver.Value{{[]byte("2020-13-45 99:00:00"), []byte("yesterday")}},
+	})
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var out bytes.Buffer
+	if _, err := sdb.ExportNDJSON(&out, rows, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"created":"2020-13-45 99:00:00","day":"yesterday"}` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package sdb_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeResult is a static result set returned by every query of a fake connection.
+type fakeResult struct {
+	columns []string
+	types   []string
+	rows    [][]driver.Value
+
+	mu    sync.Mutex
+	execs []string
+	args  [][]driver.NamedValue
+}
+
+func (r *fakeResult) executed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.execs...)
+}
+
+// +gochecknoglobals
+var fakeDB = struct {
+	sync.Mutex
+	n       int
+	results map[string]*fakeResult
+}{results: map[string]*fakeResult{}}
+
+func init() {
+	sql.Register("sdbfake", fakeDriver{})
+}
+
+// openFakeDB returns a database whose queries return res and whose execs are recorded in res.
+func openFakeDB(t *testing.T, res *fakeResult) *sql.DB {
+	t.Helper()
+
+	fakeDB.Lock()
+	fakeDB.n++
+	dsn := "fake" + strconv.Itoa(fakeDB.n)
+	fakeDB.results[dsn] = res
+	fakeDB.Unlock()
+
+	db, err := sql.Open("sdbfake", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	fakeDB.Lock()
+	defer fakeDB.Unlock()
+	res, ok := fakeDB.results[dsn]
+	if !ok {
+		return nil, errors.New("unknown dsn " + dsn)
+	}
+	return &fakeConn{res: res}, nil
+}
+
+type fakeConn struct {
+	res *fakeResult
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if query == "FAIL" {
+		return nil, errors.New("query failed")
+	}
+	return &fakeRows{res: c.res}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if query == "FAIL" {
+		return nil, errors.New("exec failed")
+	}
+	c.res.mu.Lock()
+	c.res.execs = append(c.res.execs, query)
+	c.res.args = append(c.res.args, args)
+	c.res.mu.Unlock()
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	res *fakeResult
+	i   int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.res.columns
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(i int) string {
+	return r.res.types[i]
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.res.rows) {
+		return io.EOF
+	}
+	copy(dest, r.res.rows[r.i])
+	r.i++
+	return nil
+}
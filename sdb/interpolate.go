@@ -0,0 +1,184 @@
+package sdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInterpolateArgs     = errors.New("sdb: number of args does not match placeholders")
+	errInterpolateFloat    = errors.New("sdb: NaN and Inf have no SQL literal")
+	errInterpolateNamedArg = errors.New("sdb: named args are not supported")
+
+	// ErrInterpolatedExec is returned by InterpolatedSQL.Query, unless the query was
+	// interpolated with InterpolateForExec.
+	ErrInterpolatedExec = errors.New("sdb: interpolated SQL is for debugging only and must not be executed")
+)
+
+// InterpolatedSQL is a query with inlined args for logs and MySQL clients. It is no string,
+// so it can't be passed to database/sql by accident.
+type InterpolatedSQL struct {
+	query      string
+	executable bool
+}
+
+// String returns the interpolated query
+func (q InterpolatedSQL) String() string {
+	return q.query
+}
+
+// Query returns the interpolated query for execution or ErrInterpolatedExec.
+func (q InterpolatedSQL) Query() (string, error) {
+	if !q.executable {
+		return "", ErrInterpolatedExec
+	}
+	return q.query, nil
+}
+
+// Interpolate replaces the ? placeholders of query by args rendered as MySQL literals.
+// Numbers are rendered unquoted, so LIMIT ? works. Placeholders in quoted strings,
+// identifiers and comments are left alone.
+//
+// The result is meant for logs and debugging, parameterized queries must still be executed with args.
+func Interpolate(query string, args ...interface{}) (InterpolatedSQL, error) {
+	s, err := interpolate(query, args)
+	return InterpolatedSQL{query: s}, err
+}
+
+// InterpolateForExec is Interpolate with a result that may be executed by InterpolatedSQL.Query.
+// Only use it for drivers or statements without placeholder support, escaping is not a replacement
+// for parameters.
+func InterpolateForExec(query string, args ...interface{}) (InterpolatedSQL, error) {
+	s, err := interpolate(query, args)
+	return InterpolatedSQL{query: s, executable: err == nil}, err
+}
+
+func interpolate(query string, args []interface{}) (string, error) {
+	var b strings.Builder
+	b.Grow(len(query) + 16*len(args))
+
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := skipQuoted(query, i)
+			b.WriteString(query[i:end])
+			i = end - 1
+		case c == '#' || c == '-' && strings.HasPrefix(query[i:], "-- "):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '?':
+			if n >= len(args) {
+				return "", fmt.Errorf("%w: more than %d placeholders", errInterpolateArgs, len(args))
+			}
+			lit, err := interpolateLiteral(args[n])
+			if err != nil {
+				return "", fmt.Errorf("arg %d: %w", n, err)
+			}
+			b.WriteString(lit)
+			n++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if n != len(args) {
+		return "", fmt.Errorf("%w: %d placeholders, %d args", errInterpolateArgs, n, len(args))
+	}
+	return b.String(), nil
+}
+
+// skipQuoted returns the index after the string or identifier starting at query[start]
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// interpolateLiteral renders v as MySQL literal. driver.Valuer values are resolved, numbers
+// are rendered unquoted, booleans as '1' or '0', times in MySQL datetime format with
+// microseconds and byte slices as hex literal.
+func interpolateLiteral(v interface{}) (string, error) {
+	switch v.(type) {
+	case sql.NamedArg, *sql.NamedArg:
+		return "", errInterpolateNamedArg
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL", nil
+		}
+		dv, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		v = dv
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if v == nil || rv.Kind() == reflect.Ptr {
+		return "NULL", nil
+	}
+
+	switch v := rv.Interface().(type) {
+	case bool:
+		if v {
+			return "'1'", nil
+		}
+		return "'0'", nil
+	case time.Time:
+		return "'" + v.Format(mysqlTimeFormat+".999999") + "'", nil
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'", nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", errInterpolateFloat
+		}
+		return strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()), nil
+	}
+	return "'" + EscapeString(fmt.Sprint(rv.Interface())) + "'", nil
+}
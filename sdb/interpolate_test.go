@@ -0,0 +1,95 @@
+package sdb_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func TestInterpolate(t *testing.T) {
+	name := "O'Brien"
+	var nilName *string
+	ts := time.Date(2020, 3, 4, 5, 6, 7, 500000000, time.UTC)
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+		want  string
+	}{
+		{name: "no args", query: "SELECT 1", want: "SELECT 1"},
+		{name: "numbers", query: "SELECT * FROM t LIMIT ? OFFSET ?", args: []interface{}{10, uint64(20)}, want: "SELECT * FROM t LIMIT 10 OFFSET 20"},
+		{name: "float", query: "SELECT ?, ?", args: []interface{}{2.5, float32(0.1)}, want: "SELECT 2.5, 0.1"},
+		{name: "escaped string", query: "SELECT * FROM t WHERE name = ?", args: []interface{}{"it's a \"test\"\n"}, want: `SELECT * FROM t WHERE name = 'it\'s a \"test\"\n'`},
+		{name: "pointers", query: "VALUES (?, ?)", args: []interface{}{&name, nilName}, want: `VALUES ('O\'Brien', NULL)`},
+		{name: "nil", query: "SET a = ?", args: []interface{}{nil}, want: "SET a = NULL"},
+		{name: "bool", query: "SET a = ?, b = ?", args: []interface{}{true, false}, want: "SET a = '1', b = '0'"},
+		{name: "time", query: "SET a = ?", args: []interface{}{ts}, want: "SET a = '2020-03-04 05:06:07.5'"},
+		{name: "bytes", query: "SET a = ?", args: []interface{}{[]byte{0, 0xff}}, want: "SET a = X'00ff'"},
+		{name: "valuer", query: "SET a = ?, b = ?", args: []interface{}{sql.NullInt64{Int64: 3, Valid: true}, sql.NullString{}}, want: "SET a = 3, b = NULL"},
+		{name: "quoted placeholder", query: "SELECT '?', \"a\\\"?\", `?` FROM t WHERE a = ?", args: []interface{}{1}, want: "SELECT '?', \"a\\\"?\", `?` FROM t WHERE a = 1"},
+		{name: "doubled quote", query: "SELECT 'it''s ?' WHERE a = ?", args: []interface{}{1}, want: "SELECT 'it''s ?' WHERE a = 1"},
+		{name: "comments", query: "SELECT ? /* ? */ -- ?\n# ?\n, ?", args: []interface{}{1, 2}, want: "SELECT 1 /* ? */ -- ?\n# ?\n, 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sdb.Interpolate(tt.query, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+type failingValuer struct{}
+
+func (failingValuer) Value() (driver.Value, error) {
+	return nil, errors.New("broken")
+}
+
+func TestInterpolate_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		{name: "missing arg", query: "SELECT ?, ?", args: []interface{}{1}},
+		{name: "extra arg", query: "SELECT ?", args: []interface{}{1, 2}},
+		{name: "NaN", query: "SELECT ?", args: []interface{}{math.NaN()}},
+		{name: "Inf", query: "SELECT ?", args: []interface{}{math.Inf(1)}},
+		{name: "named", query: "SELECT ?", args: []interface{}{sql.Named("a", 1)}},
+		{name: "failing valuer", query: "SELECT ?", args: []interface{}{failingValuer{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := sdb.Interpolate(tt.query, tt.args...); err == nil {
+				t.Errorf("Interpolate(%q, %v) expected error", tt.query, tt.args)
+			}
+		})
+	}
+}
+
+func TestInterpolatedSQL_Query(t *testing.T) {
+	q, err := sdb.Interpolate("SELECT ?", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Query(); !errors.Is(err, sdb.ErrInterpolatedExec) {
+		t.Errorf("Query() error = %v, want %v", err, sdb.ErrInterpolatedExec)
+	}
+
+	q, err = sdb.InterpolateForExec("SELECT ?", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := q.Query(); err != nil || got != "SELECT 1" {
+		t.Errorf("Query() = %q, %v, want %q", got, err, "SELECT 1")
+	}
+}
@@ -1,9 +1,15 @@
 package sdb
 
 import (
+	"encoding/json"
+	"io"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
+	"sync"
 
+	"unicode/utf8"
 	"unsafe"
 
 	"time"
@@ -28,19 +34,42 @@ var (
 	strBackslashLT        = []byte(`\u003c`)
 	strBackslashQ         = []byte(`\u0027`)
 	strBackslashZero      = []byte(`\u0000`)
+	strBackslashU00       = []byte(`\u00`)
+	strBackslashU202      = []byte(`\u202`)
+	strReplacementChar    = []byte("\ufffd")
+
+	hexDigits = "0123456789abcdef"
+
+	// jsonSafe reports ASCII characters which can be written without escaping.
+	jsonSafe = func() (safe [utf8.RuneSelf]bool) {
+		for c := 0x20; c < utf8.RuneSelf; c++ {
+			safe[c] = true
+		}
+		for _, c := range `"\<'` {
+			safe[c] = false
+		}
+		return safe
+	}()
 )
 
+// nolint[gochecknoblobals]
+var jsonBuffer = &sync.Pool{
+	New: func() interface{} {
+		jsonPoolCounters.newBuffer(0)
+		return &bytebufferpool.ByteBuffer{}
+	},
+}
+
 // NewJsonBuffer factory
 func NewJsonBuffer() *JsonBuffer {
-	return (*JsonBuffer)(bytebufferpool.Get())
+	b := jsonBuffer.Get().(*bytebufferpool.ByteBuffer)
+	jsonPoolCounters.get(cap(b.B))
+	return (*JsonBuffer)(b)
 }
 
 // NewLine write newline char to buffer
 func (t *JsonBuffer) NewLine() {
-	_, err := bb(t).Write([]byte{'\n'})
-	if err != nil {
-		panic(err)
-	}
+	bb(t).B = append(bb(t).B, '\n')
 }
 
 // SS shortcut for writing string to buffer and check error
@@ -50,21 +79,32 @@ func (t *JsonBuffer) SS(ss ...string) {
 	}
 }
 
-// S shortcut for writing string to buffer and check error
+// S shortcut for writing string to buffer.
+// Appending to the buffer cannot fail, write errors are reported by JsonStream.
 func (t *JsonBuffer) S(s string) {
-	if s != "" {
-		_, err := bb(t).WriteString(s)
-		if err != nil {
-			panic(err)
-		}
-	}
+	bb(t).B = append(bb(t).B, s...)
+}
+
+// Len returns the number of buffered bytes
+func (t *JsonBuffer) Len() int {
+	return len(bb(t).B)
 }
 
 // JSe shortcut for writing string to buffer and check error
 func (t *JsonBuffer) JSe(s string) {
 	t.S(`"`)
 	if s != "" {
-		t.jsonString(s)
+		t.jsonString(s, false)
+	}
+	t.S(`"`)
+}
+
+// JSeHTML like JSe, but additionally escapes '>', '&', U+2028 and U+2029,
+// so the output can be embedded into HTML script tags.
+func (t *JsonBuffer) JSeHTML(s string) {
+	t.S(`"`)
+	if s != "" {
+		t.jsonString(s, true)
 	}
 	t.S(`"`)
 }
@@ -85,15 +125,66 @@ func (t *JsonBuffer) JB(prepend, key string, value bool) {
 	}
 }
 
-// JT shortcut for writing string to JSON escaped string
+// TimeEncoding selects how time values are written to JSON. The zero value is TimeRFC3339Nano.
+type TimeEncoding int
+
+const (
+	// TimeRFC3339Nano RFC 3339 with nanoseconds and zone as string
+	TimeRFC3339Nano TimeEncoding = iota
+	// TimeGerman german date notation 02.01.2006 as string
+	TimeGerman
+	// TimeDateISO ISO date 2006-01-02 as string
+	TimeDateISO
+	// TimeUnix unix timestamp in seconds as number
+	TimeUnix
+	// TimeUnixMilli unix timestamp in milliseconds as number
+	TimeUnixMilli
+)
+
+// JT shortcut for writing time in german date notation to JSON. Zero time is written as null.
 func (t *JsonBuffer) JT(prepend, key string, value time.Time) {
+	t.JTE(prepend, key, value, TimeGerman)
+}
+
+// JTE shortcut for writing time to JSON using enc. Zero time is written as null.
+func (t *JsonBuffer) JTE(prepend, key string, value time.Time, enc TimeEncoding) {
 	if prepend != "" {
 		t.S(prepend)
 	}
 	bb(t).Write(JSONQuote)
 	t.S(key)
-	t.S(`":"`)
-	t.S(stime.FormatTime(&value))
+	t.S(`":`)
+	t.TE(value, enc)
+}
+
+// T append time as JSON string in german date notation. Zero time is written as null.
+func (t *JsonBuffer) T(value time.Time) {
+	t.TE(value, TimeGerman)
+}
+
+// TE append time without allocation using enc. Zero time is written as null.
+func (t *JsonBuffer) TE(value time.Time, enc TimeEncoding) {
+	if value.IsZero() {
+		t.S("null")
+		return
+	}
+	switch enc {
+	case TimeUnix:
+		t.D64(value.Unix())
+	case TimeUnixMilli:
+		t.D64(value.UnixMilli())
+	case TimeGerman:
+		t.appendTime(value, stime.DateLayout)
+	case TimeDateISO:
+		t.appendTime(value, "2006-01-02")
+	default:
+		t.appendTime(value, time.RFC3339Nano)
+	}
+}
+
+func (t *JsonBuffer) appendTime(value time.Time, layout string) {
+	bb(t).Write(JSONQuote)
+	bb(t).B = value.AppendFormat(bb(t).B, layout)
 	bb(t).Write(JSONQuote)
 }
 
@@ -106,7 +197,21 @@ func (t *JsonBuffer) JS(prepend, key, value string) {
 	t.S(key)
 	t.S(`":"`)
 	if value != "" {
-		t.jsonString(value)
+		t.jsonString(value, false)
+	}
+	bb(t).Write(JSONQuote)
+}
+
+// JSHTML shortcut for writing string to JSON escaped string safe for embedding into HTML
+func (t *JsonBuffer) JSHTML(prepend, key, value string) {
+	if prepend != "" {
+		t.S(prepend)
+	}
+	bb(t).Write(JSONQuote)
+	t.S(key)
+	t.S(`":"`)
+	if value != "" {
+		t.jsonString(value, true)
 	}
 	bb(t).Write(JSONQuote)
 }
@@ -156,6 +261,17 @@ func (t *JsonBuffer) JF64(prepend, key string, value float64) {
 	t.F64(value)
 }
 
+// JFP shortcut for writing float with fixed precision to JSON
+func (t *JsonBuffer) JFP(prepend, key string, value float64, prec int) {
+	if prepend != "" {
+		t.S(prepend)
+	}
+	bb(t).Write(JSONQuote)
+	t.S(key)
+	t.S(`":`)
+	t.FP(value, prec)
+}
+
 // JD shortcut for writing int to JSON escaped string
 func (t *JsonBuffer) JD(prepend, key string, value int) {
 	if prepend != "" {
@@ -189,6 +305,151 @@ func (t *JsonBuffer) JD64u(prepend, key string, value uint64) {
 	t.D64u(value)
 }
 
+// JArrayInt shortcut for writing int slice to JSON array. A nil slice is written as null.
+func (t *JsonBuffer) JArrayInt(prepend, key string, values []int) {
+	t.jKey(prepend, key)
+	if values == nil {
+		t.S("null")
+		return
+	}
+	t.S("[")
+	for i, v := range values {
+		if i > 0 {
+			t.S(",")
+		}
+		t.D(v)
+	}
+	t.S("]")
+}
+
+// JArrayString shortcut for writing string slice to JSON array. A nil slice is written as null.
+func (t *JsonBuffer) JArrayString(prepend, key string, values []string) {
+	t.jKey(prepend, key)
+	if values == nil {
+		t.S("null")
+		return
+	}
+	t.S("[")
+	for i, v := range values {
+		if i > 0 {
+			t.S(",")
+		}
+		t.JSe(v)
+	}
+	t.S("]")
+}
+
+// JArrayFloat shortcut for writing float64 slice to JSON array. A nil slice is written as null.
+func (t *JsonBuffer) JArrayFloat(prepend, key string, values []float64) {
+	t.jKey(prepend, key)
+	if values == nil {
+		t.S("null")
+		return
+	}
+	t.S("[")
+	for i, v := range values {
+		if i > 0 {
+			t.S(",")
+		}
+		t.F64(v)
+	}
+	t.S("]")
+}
+
+// JMapString shortcut for writing a map as JSON object with sorted keys. A nil map is written as null.
+func (t *JsonBuffer) JMapString(prepend, key string, m map[string]string) {
+	t.jKey(prepend, key)
+	if m == nil {
+		t.S("null")
+		return
+	}
+	t.S("{")
+	for i, k := range sortedKeys(m) {
+		if i > 0 {
+			t.S(",")
+		}
+		t.JSe(k)
+		t.S(":")
+		t.JSe(m[k])
+	}
+	t.S("}")
+}
+
+// JMapInt shortcut for writing a map as JSON object with sorted keys. A nil map is written as null.
+func (t *JsonBuffer) JMapInt(prepend, key string, m map[string]int) {
+	t.jKey(prepend, key)
+	if m == nil {
+		t.S("null")
+		return
+	}
+	t.S("{")
+	for i, k := range sortedKeys(m) {
+		if i > 0 {
+			t.S(",")
+		}
+		t.JSe(k)
+		t.S(":")
+		t.D(m[k])
+	}
+	t.S("}")
+}
+
+// JMapFloat shortcut for writing a map as JSON object with sorted keys. A nil map is written as null.
+func (t *JsonBuffer) JMapFloat(prepend, key string, m map[string]float64) {
+	t.jKey(prepend, key)
+	if m == nil {
+		t.S("null")
+		return
+	}
+	t.S("{")
+	for i, k := range sortedKeys(m) {
+		if i > 0 {
+			t.S(",")
+		}
+		t.JSe(k)
+		t.S(":")
+		t.F64(m[k])
+	}
+	t.S("}")
+}
+
+// JRaw shortcut for embedding pre-rendered JSON under key without escaping it again.
+// An empty value is written as null.
+func (t *JsonBuffer) JRaw(prepend, key string, value json.RawMessage) {
+	t.jKey(prepend, key)
+	if len(value) == 0 {
+		t.S("null")
+		return
+	}
+	bb(t).Write(value)
+}
+
+// JNull shortcut for writing null value to JSON
+func (t *JsonBuffer) JNull(prepend, key string) {
+	t.jKey(prepend, key)
+	t.S("null")
+}
+
+// jKey writes prepend and the quoted key followed by a colon
+func (t *JsonBuffer) jKey(prepend, key string) {
+	if prepend != "" {
+		t.S(prepend)
+	}
+	bb(t).Write(JSONQuote)
+	t.S(key)
+	t.S(`":`)
+}
+
+// sortedKeys returns the keys of m in ascending order for deterministic output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // only for testing
 func (t *JsonBuffer) Reset() {
 	bb(t).Reset()
@@ -199,14 +460,26 @@ func (t *JsonBuffer) Space() {
 	t.S(" ")
 }
 
-// F append integer without allocation
+// F append float32 without allocation using the shortest representation that round trips.
+// NaN and Inf are written as null.
 func (t *JsonBuffer) F(f float32) {
-	bb(t).B = strconv.AppendFloat(bb(t).B, float64(f), 'f', 2, 64)
+	bb(t).B = appendJSONFloat(bb(t).B, float64(f), 32)
 }
 
-// F64 append integer without allocation
+// F64 append float64 without allocation using the shortest representation that round trips.
+// NaN and Inf are written as null.
 func (t *JsonBuffer) F64(f float64) {
-	bb(t).B = strconv.AppendFloat(bb(t).B, f, 'f', 2, 64)
+	bb(t).B = appendJSONFloat(bb(t).B, f, 64)
+}
+
+// FP append float64 with a fixed number of decimals, e.g. 2 for currency.
+// NaN and Inf are written as null.
+func (t *JsonBuffer) FP(f float64, prec int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		t.S("null")
+		return
+	}
+	bb(t).B = strconv.AppendFloat(bb(t).B, f, 'f', prec, 64)
 }
 
 // D append integer without allocation
@@ -229,11 +502,65 @@ func (t *JsonBuffer) D64(n int64) {
 	bb(t).B = strconv.AppendInt(bb(t).B, n, 10)
 }
 
-// Bytes returns buffer contents
-// Attention: returns the buffer to the pool and sets pointer to nil
+// Bytes returns a copy of the buffer contents and returns the buffer to the pool.
+// The buffer must not be used afterwards. Calling Bytes, WriteTo or Release again
+// puts the buffer into the pool twice, so two later NewJsonBuffer calls share it.
+// The debug mode, see EnablePoolDebug, panics instead.
 func (t *JsonBuffer) Bytes() []byte {
-	b := bb(t).Bytes()
-	bytebufferpool.Put(bb(t))
+	if poolDebugEnabled() {
+		t.checkReleased()
+	}
+	b := make([]byte, len(bb(t).B))
+	copy(b, bb(t).B)
+	t.Release()
+	return b
+}
+
+// WriteTo writes the buffer contents to w and returns the buffer to the pool.
+// The buffer must not be used afterwards. Releasing it again is a bug, see Bytes.
+func (t *JsonBuffer) WriteTo(w io.Writer) (int64, error) {
+	if poolDebugEnabled() {
+		t.checkReleased()
+	}
+	n, err := w.Write(bb(t).B)
+	t.Release()
+	return int64(n), err
+}
+
+// Release returns the buffer to the pool without reading it.
+// The buffer must not be used afterwards. Releasing it again is a bug, see Bytes.
+func (t *JsonBuffer) Release() {
+	if poolDebugEnabled() {
+		releaseJSONBuffer(t)
+		return
+	}
+	bb(t).Reset()
+	if jsonPoolCounters.put(cap(bb(t).B)) {
+		jsonBuffer.Put(bb(t))
+	}
+}
+
+// appendJSONFloat formats like encoding/json: shortest round trip, exponent
+// notation only for very small or large values, null for non finite values.
+func appendJSONFloat(b []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(b, "null"...)
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
 	return b
 }
 
@@ -241,7 +568,7 @@ func bb(t *JsonBuffer) *bytebufferpool.ByteBuffer {
 	return (*bytebufferpool.ByteBuffer)(t)
 }
 
-func (t *JsonBuffer) jsonString(s string) {
+func (t *JsonBuffer) jsonString(s string, html bool) {
 	write := bb(t).Write
 	b := s2b(s)
 	j := 0
@@ -250,49 +577,64 @@ func (t *JsonBuffer) jsonString(s string) {
 		// Hint the compiler to remove bounds checks in the loop below.
 		_ = b[n-1]
 	}
-	for i := 0; i < n; i++ {
-		switch b[i] {
-		case '"':
-			write(b[j:i])
-			write(strBackslashQuote)
-			j = i + 1
-		case '\\':
-			write(b[j:i])
-			write(strBackslashBackslash)
-			j = i + 1
-		case '\n':
-			write(b[j:i])
-			write(strBackslashN)
-			j = i + 1
-		case '\r':
-			write(b[j:i])
-			write(strBackslashR)
-			j = i + 1
-		case '\t':
+	for i := 0; i < n; {
+		c := b[i]
+		if c < utf8.RuneSelf {
+			if jsonSafe[c] && !(html && (c == '>' || c == '&')) {
+				i++
+				continue
+			}
 			write(b[j:i])
-			write(strBackslashT)
-			j = i + 1
-		case '\f':
-			write(b[j:i])
-			write(strBackslashF)
-			j = i + 1
-		case '\b':
-			write(b[j:i])
-			write(strBackslashB)
-			j = i + 1
-		case '<':
-			write(b[j:i])
-			write(strBackslashLT)
-			j = i + 1
-		case '\'':
+			switch c {
+			case '"':
+				write(strBackslashQuote)
+			case '\\':
+				write(strBackslashBackslash)
+			case '\n':
+				write(strBackslashN)
+			case '\r':
+				write(strBackslashR)
+			case '\t':
+				write(strBackslashT)
+			case '\f':
+				write(strBackslashF)
+			case '\b':
+				write(strBackslashB)
+			case '<':
+				write(strBackslashLT)
+			case '\'':
+				write(strBackslashQ)
+			case 0:
+				write(strBackslashZero)
+			default:
+				// remaining control characters and '>', '&' in html mode
+				write(strBackslashU00)
+				_ = bb(t).WriteByte(hexDigits[c>>4])
+				_ = bb(t).WriteByte(hexDigits[c&0xF])
+			}
+			i++
+			j = i
+			continue
+		}
+
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size == 1 {
+			// invalid UTF-8 is replaced byte by byte like encoding/json does
 			write(b[j:i])
-			write(strBackslashQ)
-			j = i + 1
-		case 0:
+			write(strReplacementChar)
+			i++
+			j = i
+			continue
+		}
+		if html && (r == '\u2028' || r == '\u2029') {
 			write(b[j:i])
-			write(strBackslashZero)
-			j = i + 1
+			write(strBackslashU202)
+			_ = bb(t).WriteByte(hexDigits[r&0xF])
+			i += size
+			j = i
+			continue
 		}
+		i += size
 	}
 	write(b[j:])
 }
@@ -306,12 +648,11 @@ func b2s(b []byte) string {
 	return *(*string)(unsafe.Pointer(&b))
 }
 
-func s2b(s string) []byte {
+func s2b(s string) (b []byte) {
+	bh := (*reflect.SliceHeader)(unsafe.Pointer(&b))
 	sh := (*reflect.StringHeader)(unsafe.Pointer(&s))
-	bh := reflect.SliceHeader{
-		Data: sh.Data,
-		Len:  sh.Len,
-		Cap:  sh.Len,
-	}
-	return *(*[]byte)(unsafe.Pointer(&bh))
+	bh.Data = sh.Data
+	bh.Len = sh.Len
+	bh.Cap = sh.Len
+	return b
 }
@@ -0,0 +1,327 @@
+package sdb_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func TestJsonBuffer_F64(t *testing.T) {
+	tests := []struct {
+		name string
+		in   float64
+		want string
+	}{
+		{name: "zero", in: 0, want: "0"},
+		{name: "integer", in: 12, want: "12"},
+		{name: "small", in: 0.004, want: "0.004"},
+		{name: "negative", in: -1.5, want: "-1.5"},
+		{name: "tiny", in: 1e-9, want: "1e-9"},
+		{name: "large", in: 1e21, want: "1e+21"},
+		{name: "NaN", in: math.NaN(), want: "null"},
+		{name: "+Inf", in: math.Inf(1), want: "null"},
+		{name: "-Inf", in: math.Inf(-1), want: "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			buf.F64(tt.in)
+			if got := string(buf.Bytes()); got != tt.want {
+				t.Errorf("F64(%v) = '%s', want '%s'", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJsonBuffer_F(t *testing.T) {
+	buf := sdb.NewJsonBuffer()
+	buf.F(0.1)
+	if got := string(buf.Bytes()); got != "0.1" {
+		t.Errorf("F(0.1) = '%s', want '%s'", got, "0.1")
+	}
+}
+
+func TestJsonBuffer_FP(t *testing.T) {
+	tests := []struct {
+		name string
+		in   float64
+		prec int
+		want string
+	}{
+		{name: "currency", in: 12.5, prec: 2, want: "12.50"},
+		{name: "round", in: 0.004, prec: 2, want: "0.00"},
+		{name: "no decimals", in: 2.5, prec: 0, want: "2"},
+		{name: "NaN", in: math.NaN(), prec: 2, want: "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			buf.FP(tt.in, tt.prec)
+			if got := string(buf.Bytes()); got != tt.want {
+				t.Errorf("FP(%v, %d) = '%s', want '%s'", tt.in, tt.prec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJsonBuffer_JF(t *testing.T) {
+	buf := sdb.NewJsonBuffer()
+	buf.S("{")
+	buf.JF64("", "a", 0.004)
+	buf.JFP(",", "b", 3, 2)
+	buf.JF(",", "c", float32(math.Inf(1)))
+	buf.S("}")
+	want := `{"a":0.004,"b":3.00,"c":null}`
+	if got := string(buf.Bytes()); got != want {
+		t.Errorf("got '%s', want '%s'", got, want)
+	}
+}
+
+func TestJsonBuffer_JSe(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		html string
+	}{
+		{name: "empty", in: "", want: `""`, html: `""`},
+		{name: "plain", in: "abc äöü", want: `"abc äöü"`, html: `"abc äöü"`},
+		{name: "quote backslash", in: `a"b\c`, want: `"a\"b\\c"`, html: `"a\"b\\c"`},
+		{name: "whitespace", in: "a\nb\rc\td", want: `"a\nb\rc\td"`, html: `"a\nb\rc\td"`},
+		{name: "control", in: "\x00\x01\x1f\x7f\b\f", want: `"\u0000\u0001\u001f` + "\x7f" + `\u0008\u000c"`, html: `"\u0000\u0001\u001f` + "\x7f" + `\u0008\u000c"`},
+		{name: "html", in: `<a href='x'>&`, want: `"\u003ca href=\u0027x\u0027>&"`, html: `"\u003ca href=\u0027x\u0027\u003e\u0026"`},
+		{name: "line separators", in: "a\u2028b\u2029", want: "\"a\u2028b\u2029\"", html: `"a\u2028b\u2029"`},
+		{name: "invalid utf8", in: "a\xffb\xc3", want: "\"a\ufffdb\ufffd\"", html: "\"a\ufffdb\ufffd\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			buf.JSe(tt.in)
+			if got := string(buf.Bytes()); got != tt.want {
+				t.Errorf("JSe(%q) = '%s', want '%s'", tt.in, got, tt.want)
+			}
+
+			buf = sdb.NewJsonBuffer()
+			buf.JSeHTML(tt.in)
+			if got := string(buf.Bytes()); got != tt.html {
+				t.Errorf("JSeHTML(%q) = '%s', want '%s'", tt.in, got, tt.html)
+			}
+		})
+	}
+}
+
+func FuzzJsonBuffer_JSe(f *testing.F) {
+	for _, s := range []string{"", "abc", "\"\\\n\r\t\b\f", "\x00\x01\x1f", "<>&'", "\u2028\u2029", "\xff\xfe", "äöü€𝄞"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		// invalid UTF-8 bytes are replaced one by one
+		want := string([]rune(s))
+		for _, html := range []bool{false, true} {
+			buf := sdb.NewJsonBuffer()
+			if html {
+				buf.JSeHTML(s)
+			} else {
+				buf.JSe(s)
+			}
+			b := buf.Bytes()
+
+			var got string
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("JSe(%q) = '%s' is invalid json: %v", s, b, err)
+			}
+			if got != want {
+				t.Fatalf("JSe(%q) round trip = %q, want %q", s, got, want)
+			}
+		}
+	})
+}
+
+func TestJsonBuffer_Collections(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(buf *sdb.JsonBuffer)
+		want  string
+	}{
+		{
+			name:  "int array",
+			write: func(buf *sdb.JsonBuffer) { buf.JArrayInt("", "a", []int{1, -2, 3}) },
+			want:  `{"a":[1,-2,3]}`,
+		},
+		{
+			name:  "nil int array",
+			write: func(buf *sdb.JsonBuffer) { buf.JArrayInt("", "a", nil) },
+			want:  `{"a":null}`,
+		},
+		{
+			name:  "empty string array",
+			write: func(buf *sdb.JsonBuffer) { buf.JArrayString("", "a", []string{}) },
+			want:  `{"a":[]}`,
+		},
+		{
+			name:  "string array",
+			write: func(buf *sdb.JsonBuffer) { buf.JArrayString("", "a", []string{"x", `"y"`}) },
+			want:  `{"a":["x","\"y\""]}`,
+		},
+		{
+			name:  "float array",
+			write: func(buf *sdb.JsonBuffer) { buf.JArrayFloat("", "a", []float64{0.5, 2}) },
+			want:  `{"a":[0.5,2]}`,
+		},
+		{
+			name:  "string map",
+			write: func(buf *sdb.JsonBuffer) { buf.JMapString("", "m", map[string]string{"b": "2", "a": "1", `"`: ""}) },
+			want:  `{"m":{"\"":"","a":"1","b":"2"}}`,
+		},
+		{
+			name:  "int map",
+			write: func(buf *sdb.JsonBuffer) { buf.JMapInt("", "m", map[string]int{"z": 26, "a": 1}) },
+			want:  `{"m":{"a":1,"z":26}}`,
+		},
+		{
+			name:  "float map",
+			write: func(buf *sdb.JsonBuffer) { buf.JMapFloat("", "m", nil) },
+			want:  `{"m":null}`,
+		},
+		{
+			name: "raw",
+			write: func(buf *sdb.JsonBuffer) {
+				buf.JRaw("", "r", json.RawMessage(`{"x":[1,2]}`))
+				buf.JRaw(",", "n", nil)
+			},
+			want: `{"r":{"x":[1,2]},"n":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			buf.S("{")
+			tt.write(buf)
+			buf.S("}")
+			got := string(buf.Bytes())
+			if got != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Errorf("invalid json '%s'", got)
+			}
+		})
+	}
+}
+
+func TestJsonBuffer_JTE(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	ts := time.Date(2020, 3, 4, 5, 6, 7, 8000000, loc)
+	tests := []struct {
+		name  string
+		value time.Time
+		enc   sdb.TimeEncoding
+		want  string
+	}{
+		{name: "german", value: ts, enc: sdb.TimeGerman, want: `{"t":"04.03.2020"}`},
+		{name: "rfc3339", value: ts, enc: sdb.TimeRFC3339Nano, want: `{"t":"2020-03-04T05:06:07.008+01:00"}`},
+		{name: "iso date", value: ts, enc: sdb.TimeDateISO, want: `{"t":"2020-03-04"}`},
+		{name: "unix", value: ts, enc: sdb.TimeUnix, want: `{"t":1583294767}`},
+		{name: "unix millis", value: ts, enc: sdb.TimeUnixMilli, want: `{"t":1583294767008}`},
+		{name: "zero", value: time.Time{}, enc: sdb.TimeRFC3339Nano, want: `{"t":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			buf.S("{")
+			buf.JTE("", "t", tt.value, tt.enc)
+			buf.S("}")
+			if got := string(buf.Bytes()); got != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJsonBuffer_JT(t *testing.T) {
+	buf := sdb.NewJsonBuffer()
+	buf.S("{")
+	buf.JT("", "a", time.Date(2016, 12, 10, 0, 0, 0, 0, time.UTC))
+	buf.JT(",", "b", time.Time{})
+	buf.S("}")
+	want := `{"a":"10.12.2016","b":null}`
+	if got := string(buf.Bytes()); got != want {
+		t.Errorf("got '%s', want '%s'", got, want)
+	}
+}
+
+// TestJsonBuffer_Bytes_DataRace tests that Bytes() returns data that won't be corrupted
+// when the buffer is returned to the pool and reused.
+func TestJsonBuffer_Bytes_DataRace(t *testing.T) {
+	buf := sdb.NewJsonBuffer()
+	buf.JS("{", "name", "first")
+	buf.S("}")
+	result := buf.Bytes()
+
+	// reuse the pool to overwrite a buffer aliasing the result
+	buf2 := sdb.NewJsonBuffer()
+	buf2.JS("{", "name", "XXXXXXXXXXXXXXXXXXXXXXXX")
+	buf2.S("}")
+	_ = buf2.Bytes()
+
+	want := `{"name":"first"}`
+	if got := string(result); got != want {
+		t.Errorf("Bytes() data was corrupted after buffer reuse!\ngot:  '%s'\nwant: '%s'", got, want)
+	}
+}
+
+// TestJsonBuffer_Bytes_Concurrent must be run with -race to detect shared memory.
+func TestJsonBuffer_Bytes_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				buf := sdb.NewJsonBuffer()
+				buf.JD("{", "g", g)
+				buf.JD(",", "i", i)
+				buf.S("}")
+				got := buf.Bytes()
+
+				other := sdb.NewJsonBuffer()
+				other.S("overwrite the pooled buffer")
+				other.Release()
+
+				want := `{"g":` + strconv.Itoa(g) + `,"i":` + strconv.Itoa(i) + `}`
+				if string(got) != want {
+					t.Errorf("got '%s', want '%s'", got, want)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestJsonBuffer_WriteTo(t *testing.T) {
+	buf := sdb.NewJsonBuffer()
+	buf.JSe("hello")
+
+	var out bytes.Buffer
+	n, err := buf.WriteTo(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 7 || out.String() != `"hello"` {
+		t.Errorf("WriteTo() = %d '%s', want %d '%s'", n, out.String(), 7, `"hello"`)
+	}
+
+	buf = sdb.NewJsonBuffer()
+	buf.JSe("hello")
+	if _, err := buf.WriteTo(failingWriter{}); !errors.Is(err, errWrite) {
+		t.Errorf("WriteTo() error = %v, want %v", err, errWrite)
+	}
+}
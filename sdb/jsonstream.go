@@ -0,0 +1,115 @@
+package sdb
+
+import (
+	"io"
+	"net/http"
+)
+
+// DefaultStreamThreshold buffer size in bytes after which a JsonStream flushes
+const DefaultStreamThreshold = 32 * 1024
+
+// JsonStream is a JsonBuffer that is flushed to an io.Writer, so large
+// exports only hold about threshold bytes in memory.
+//
+// All JsonBuffer writers are available. Call Checkpoint (or NewLine for
+// NDJSON) after each record to flush once the threshold is exceeded and
+// Close at the end to write the rest and return the buffer to the pool.
+// Bytes, WriteTo and Release are shadowed, so the pooled buffer is only
+// returned by Close or Release of the stream.
+type JsonStream struct {
+	*JsonBuffer
+	w         io.Writer
+	threshold int
+	err       error
+}
+
+// NewJsonStream returns a stream writing to w. If w implements http.Flusher, it is flushed
+// after every write. A threshold <= 0 uses DefaultStreamThreshold.
+func NewJsonStream(w io.Writer, threshold int) *JsonStream {
+	if threshold <= 0 {
+		threshold = DefaultStreamThreshold
+	}
+	return &JsonStream{
+		JsonBuffer: NewJsonBuffer(),
+		w:          w,
+		threshold:  threshold,
+	}
+}
+
+// NewLine writes a newline and flushes, if the threshold is exceeded.
+func (s *JsonStream) NewLine() error {
+	s.JsonBuffer.NewLine()
+	return s.Checkpoint()
+}
+
+// Checkpoint flushes the buffer, if it exceeds the threshold.
+func (s *JsonStream) Checkpoint() error {
+	if s.JsonBuffer.Len() < s.threshold {
+		return s.err
+	}
+	return s.Flush()
+}
+
+// Flush writes the buffered data to the underlying writer.
+//
+// The first write error is returned by all following calls. After an error
+// buffered data is discarded to keep memory usage constant.
+func (s *JsonStream) Flush() error {
+	if s.err != nil {
+		s.JsonBuffer.Reset()
+		return s.err
+	}
+	if s.JsonBuffer.Len() > 0 {
+		_, s.err = s.w.Write(bb(s.JsonBuffer).B)
+		s.JsonBuffer.Reset()
+		if s.err != nil {
+			return s.err
+		}
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Err returns the first write error.
+func (s *JsonStream) Err() error {
+	return s.err
+}
+
+// Close flushes the remaining data and returns the buffer to the pool.
+// The stream must not be used afterwards.
+func (s *JsonStream) Close() error {
+	if s.JsonBuffer == nil {
+		return s.err
+	}
+	err := s.Flush()
+	s.Release()
+	return err
+}
+
+// Bytes returns a copy of the data not flushed yet. Unlike JsonBuffer.Bytes the buffer
+// is kept, so the stream can still be used.
+func (s *JsonStream) Bytes() []byte {
+	b := make([]byte, s.JsonBuffer.Len())
+	copy(b, bb(s.JsonBuffer).B)
+	return b
+}
+
+// WriteTo writes the data not flushed yet to w instead of the stream's writer and
+// discards it. Unlike JsonBuffer.WriteTo the buffer is kept, so the stream can still be used.
+func (s *JsonStream) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(bb(s.JsonBuffer).B)
+	s.JsonBuffer.Reset()
+	return int64(n), err
+}
+
+// Release returns the buffer to the pool without flushing it. The stream must not be used
+// afterwards, Close only returns the first write error.
+func (s *JsonStream) Release() {
+	if s.JsonBuffer == nil {
+		return
+	}
+	s.JsonBuffer.Release()
+	s.JsonBuffer = nil
+}
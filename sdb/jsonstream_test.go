@@ -0,0 +1,132 @@
+package sdb_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestJsonStream(t *testing.T) {
+	w := &countingWriter{}
+	s := sdb.NewJsonStream(w, 64)
+
+	for i := 0; i < 100; i++ {
+		s.S("{")
+		s.JD("", "id", i)
+		s.JS(",", "name", "row")
+		s.S("}")
+		if err := s.NewLine(); err != nil {
+			t.Fatal(err)
+		}
+		if s.Len() >= 64 {
+			t.Fatalf("buffer holds %d bytes after NewLine, want < 64", s.Len())
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("got %d lines, want %d", len(lines), 100)
+	}
+	for _, l := range lines {
+		if !json.Valid([]byte(l)) {
+			t.Fatalf("invalid json line '%s'", l)
+		}
+	}
+	if w.writes < 10 {
+		t.Errorf("got %d writes, want streaming in chunks", w.writes)
+	}
+}
+
+func TestJsonStream_HTTPFlusher(t *testing.T) {
+	rec := httptest.NewRecorder()
+	s := sdb.NewJsonStream(rec, 0)
+	s.JSe("hello")
+	if rec.Flushed {
+		t.Fatal("flushed before threshold")
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !rec.Flushed {
+		t.Error("http.Flusher was not called")
+	}
+	if got := rec.Body.String(); got != `"hello"` {
+		t.Errorf("got '%s', want '%s'", got, `"hello"`)
+	}
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestJsonStream_WriteError(t *testing.T) {
+	s := sdb.NewJsonStream(failingWriter{}, 1)
+	s.JSe("a")
+	if err := s.Checkpoint(); !errors.Is(err, errWrite) {
+		t.Fatalf("Checkpoint() error = %v, want %v", err, errWrite)
+	}
+
+	s.JSe("b")
+	if err := s.NewLine(); !errors.Is(err, errWrite) {
+		t.Fatalf("NewLine() error = %v, want %v", err, errWrite)
+	}
+	if s.Len() != 0 {
+		t.Errorf("buffer holds %d bytes after error, want 0", s.Len())
+	}
+	if err := s.Close(); !errors.Is(err, errWrite) {
+		t.Errorf("Close() error = %v, want %v", err, errWrite)
+	}
+	if err := s.Err(); !errors.Is(err, errWrite) {
+		t.Errorf("Err() = %v, want %v", err, errWrite)
+	}
+}
+
+func TestJsonStream_ShadowedBufferMethods(t *testing.T) {
+	var out, other bytes.Buffer
+	s := sdb.NewJsonStream(&out, 0)
+
+	s.S(`[1`)
+	if got := string(s.Bytes()); got != "[1" {
+		t.Errorf("Bytes() = %q, want %q", got, "[1")
+	}
+	s.S(`,2`)
+	if n, err := s.WriteTo(&other); err != nil || n != 4 || other.String() != "[1,2" {
+		t.Errorf("WriteTo() = %d, %v, wrote %q", n, err, other.String())
+	}
+	s.S(`]`)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "]" {
+		t.Errorf("stream wrote %q, want %q", out.String(), "]")
+	}
+
+	s = sdb.NewJsonStream(&out, 0)
+	s.S(`discarded`)
+	s.Release()
+	if err := s.Close(); err != nil || out.String() != "]" {
+		t.Errorf("Close() after Release() = %v, wrote %q", err, out.String())
+	}
+}
@@ -0,0 +1,244 @@
+package sdb
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errJSONKeyOutsideObject = errors.New("json: key outside of object")
+	errJSONMissingKey       = errors.New("json: object value without key")
+	errJSONMissingValue     = errors.New("json: key without value")
+	errJSONMismatchedEnd    = errors.New("json: mismatched end of object or array")
+	errJSONUnclosed         = errors.New("json: unclosed object or array")
+	errJSONMultipleRoots    = errors.New("json: more than one top-level value")
+)
+
+type jsonScopeKind uint8
+
+const (
+	jsonScopeObject jsonScopeKind = iota + 1
+	jsonScopeArray
+)
+
+type jsonScope struct {
+	kind     jsonScopeKind
+	n        int
+	afterKey bool
+}
+
+// JsonWriter writes structured JSON to a JsonBuffer and inserts commas automatically.
+//
+//	w := sdb.NewJsonWriter(buf)
+//	w.BeginObject()
+//	w.Key("id")
+//	w.Int(1)
+//	w.EndObject()
+type JsonWriter struct {
+	buf    *JsonBuffer
+	scopes []jsonScope
+	stack  [16]jsonScope
+	strict bool
+	root   bool
+	err    error
+}
+
+// NewJsonWriter returns a writer appending to buf.
+func NewJsonWriter(buf *JsonBuffer) *JsonWriter {
+	w := &JsonWriter{buf: buf}
+	w.scopes = w.stack[:0]
+	return w
+}
+
+// Strict enables panicking on the first nesting error instead of only recording it. Meant for tests.
+func (w *JsonWriter) Strict(strict bool) *JsonWriter {
+	w.strict = strict
+	return w
+}
+
+// Buffer returns the underlying buffer.
+func (w *JsonWriter) Buffer() *JsonBuffer {
+	return w.buf
+}
+
+// Depth returns the current nesting depth.
+func (w *JsonWriter) Depth() int {
+	return len(w.scopes)
+}
+
+// Err returns the first nesting error.
+func (w *JsonWriter) Err() error {
+	return w.err
+}
+
+// Close checks that all objects and arrays have been closed and returns the first nesting error.
+func (w *JsonWriter) Close() error {
+	if len(w.scopes) > 0 {
+		w.fail(errJSONUnclosed)
+	}
+	return w.err
+}
+
+// BeginObject starts a new object as a value.
+func (w *JsonWriter) BeginObject() {
+	w.value()
+	w.buf.S("{")
+	w.scopes = append(w.scopes, jsonScope{kind: jsonScopeObject})
+}
+
+// EndObject closes the current object.
+func (w *JsonWriter) EndObject() {
+	w.end(jsonScopeObject)
+	w.buf.S("}")
+}
+
+// BeginArray starts a new array as a value.
+func (w *JsonWriter) BeginArray() {
+	w.value()
+	w.buf.S("[")
+	w.scopes = append(w.scopes, jsonScope{kind: jsonScopeArray})
+}
+
+// EndArray closes the current array.
+func (w *JsonWriter) EndArray() {
+	w.end(jsonScopeArray)
+	w.buf.S("]")
+}
+
+// Key writes an object key. It must be followed by exactly one value.
+func (w *JsonWriter) Key(key string) {
+	if len(w.scopes) == 0 || w.top().kind != jsonScopeObject {
+		w.fail(errJSONKeyOutsideObject)
+	} else {
+		s := w.top()
+		if s.afterKey {
+			w.fail(errJSONMissingValue)
+		}
+		if s.n > 0 {
+			w.buf.S(",")
+		}
+		s.n++
+		s.afterKey = true
+	}
+	w.buf.JSe(key)
+	w.buf.S(":")
+}
+
+// String writes an escaped string value.
+func (w *JsonWriter) String(s string) {
+	w.value()
+	w.buf.JSe(s)
+}
+
+// Int writes an int value.
+func (w *JsonWriter) Int(n int) {
+	w.value()
+	w.buf.D(n)
+}
+
+// Int64 writes an int64 value.
+func (w *JsonWriter) Int64(n int64) {
+	w.value()
+	w.buf.D64(n)
+}
+
+// Uint64 writes an uint64 value.
+func (w *JsonWriter) Uint64(n uint64) {
+	w.value()
+	w.buf.D64u(n)
+}
+
+// Float64 writes a float64 value.
+func (w *JsonWriter) Float64(f float64) {
+	w.value()
+	w.buf.F64(f)
+}
+
+// Float64Prec writes a float64 value with a fixed number of decimals.
+func (w *JsonWriter) Float64Prec(f float64, prec int) {
+	w.value()
+	w.buf.FP(f, prec)
+}
+
+// Bool writes a boolean value.
+func (w *JsonWriter) Bool(b bool) {
+	w.value()
+	if b {
+		w.buf.S("true")
+	} else {
+		w.buf.S("false")
+	}
+}
+
+// Time writes a time value in the same format as JsonBuffer.JT.
+func (w *JsonWriter) Time(t time.Time) {
+	w.value()
+	w.buf.T(t)
+}
+
+// TimeEncoded writes a time value using enc.
+func (w *JsonWriter) TimeEncoded(t time.Time, enc TimeEncoding) {
+	w.value()
+	w.buf.TE(t, enc)
+}
+
+// Null writes null.
+func (w *JsonWriter) Null() {
+	w.value()
+	w.buf.S("null")
+}
+
+// Raw writes already encoded JSON as a value without validating it.
+func (w *JsonWriter) Raw(b []byte) {
+	w.value()
+	_, _ = bb(w.buf).Write(b)
+}
+
+func (w *JsonWriter) top() *jsonScope {
+	return &w.scopes[len(w.scopes)-1]
+}
+
+// value prepares the buffer for the next value: inserts a comma inside arrays
+// and checks that object values are preceded by a key.
+func (w *JsonWriter) value() {
+	if len(w.scopes) == 0 {
+		if w.root {
+			w.fail(errJSONMultipleRoots)
+		}
+		w.root = true
+		return
+	}
+	s := w.top()
+	switch s.kind {
+	case jsonScopeObject:
+		if !s.afterKey {
+			w.fail(errJSONMissingKey)
+		}
+		s.afterKey = false
+	case jsonScopeArray:
+		if s.n > 0 {
+			w.buf.S(",")
+		}
+		s.n++
+	}
+}
+
+func (w *JsonWriter) end(kind jsonScopeKind) {
+	if len(w.scopes) == 0 || w.top().kind != kind {
+		w.fail(errJSONMismatchedEnd)
+		return
+	}
+	if w.top().afterKey {
+		w.fail(errJSONMissingValue)
+	}
+	w.scopes = w.scopes[:len(w.scopes)-1]
+}
+
+func (w *JsonWriter) fail(err error) {
+	if w.strict {
+		panic(err)
+	}
+	if w.err == nil {
+		w.err = err
+	}
+}
@@ -0,0 +1,168 @@
+package sdb_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func TestJsonWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w *sdb.JsonWriter)
+		want  string
+	}{
+		{
+			name: "empty object",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				w.EndObject()
+			},
+			want: `{}`,
+		},
+		{
+			name: "flat object",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				w.Key("id")
+				w.Int(1)
+				w.Key("name")
+				w.String(`a "b"`)
+				w.Key("active")
+				w.Bool(true)
+				w.Key("parent")
+				w.Null()
+				w.EndObject()
+			},
+			want: `{"id":1,"name":"a \"b\"","active":true,"parent":null}`,
+		},
+		{
+			name: "conditional fields",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				for i, skip := range []bool{true, false, true, false} {
+					if skip {
+						continue
+					}
+					w.Key("f")
+					w.Int(i)
+				}
+				w.EndObject()
+			},
+			want: `{"f":1,"f":3}`,
+		},
+		{
+			name: "nested",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginArray()
+				w.BeginObject()
+				w.Key("ids")
+				w.BeginArray()
+				w.Int64(1)
+				w.Uint64(2)
+				w.EndArray()
+				w.Key("raw")
+				w.Raw([]byte(`{"x":1}`))
+				w.EndObject()
+				w.BeginArray()
+				w.EndArray()
+				w.EndArray()
+			},
+			want: `[{"ids":[1,2],"raw":{"x":1}},[]]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			w := sdb.NewJsonWriter(buf).Strict(true)
+			tt.write(w)
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			got := string(buf.Bytes())
+			if got != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Errorf("invalid json '%s'", got)
+			}
+		})
+	}
+}
+
+func TestJsonWriterErr(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w *sdb.JsonWriter)
+	}{
+		{
+			name: "unclosed",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+			},
+		},
+		{
+			name: "mismatched end",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				w.EndArray()
+			},
+		},
+		{
+			name: "value without key",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				w.Int(1)
+				w.EndObject()
+			},
+		},
+		{
+			name: "key without value",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				w.Key("a")
+				w.EndObject()
+			},
+		},
+		{
+			name: "second root value",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginObject()
+				w.EndObject()
+				w.Int(1)
+			},
+		},
+		{
+			name: "key in array",
+			write: func(w *sdb.JsonWriter) {
+				w.BeginArray()
+				w.Key("a")
+				w.Int(1)
+				w.EndArray()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := sdb.NewJsonBuffer()
+			defer buf.Bytes()
+
+			w := sdb.NewJsonWriter(buf)
+			tt.write(w)
+			if err := w.Close(); err == nil {
+				t.Error("expected nesting error")
+			}
+
+			buf.Reset()
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic in strict mode")
+				}
+			}()
+			w = sdb.NewJsonWriter(buf).Strict(true)
+			tt.write(w)
+			_ = w.Close()
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package sdb
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// DefaultPoolMaxCapacity buffers growing beyond this capacity in bytes are not returned to the pools.
+const DefaultPoolMaxCapacity = 1 << 20
+
+// nolint[gochecknoblobals]
+var (
+	poolMaxCapacity int64 = DefaultPoolMaxCapacity
+	poolStatsOn     int32
+
+	sqlPoolCounters  poolCounters
+	jsonPoolCounters poolCounters
+)
+
+// PoolStat counters of a buffer pool. They are only updated while enabled by EnablePoolStats.
+type PoolStat struct {
+	// Gets buffers taken from the pool
+	Gets uint64
+	// News buffers allocated because the pool was empty
+	News uint64
+	// Puts buffers returned to the pool
+	Puts uint64
+	// Discards buffers dropped on release, because they exceeded the max capacity
+	Discards uint64
+	// RetainedBytes capacity of the buffers in the pool. Approximation, because
+	// buffers dropped by the garbage collector are not subtracted and buffers
+	// pooled before enabling the counters are not added.
+	RetainedBytes int64
+}
+
+// PoolStats counters of the SQLStatement and JsonBuffer pools
+type PoolStats struct {
+	SQLStatement PoolStat
+	JsonBuffer   PoolStat
+}
+
+// SetPoolMaxCapacity sets the maximum capacity of buffers kept by the SQLStatement
+// and JsonBuffer pools. Larger buffers are discarded on release. n <= 0 disables the limit.
+func SetPoolMaxCapacity(n int) {
+	atomic.StoreInt64(&poolMaxCapacity, int64(n))
+}
+
+// EnablePoolStats switches the pool counters on or off.
+func EnablePoolStats(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&poolStatsOn, v)
+}
+
+// GetPoolStats returns a snapshot of the pool counters.
+func GetPoolStats() PoolStats {
+	return PoolStats{
+		SQLStatement: sqlPoolCounters.stat(),
+		JsonBuffer:   jsonPoolCounters.stat(),
+	}
+}
+
+// PublishPoolStats exports the pool counters with expvar under name, e.g. "sdb_pools".
+// Like expvar.Publish it panics, if name is already registered.
+func PublishPoolStats(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return GetPoolStats()
+	}))
+}
+
+type poolCounters struct {
+	gets     uint64
+	news     uint64
+	puts     uint64
+	discards uint64
+	retained int64
+}
+
+func poolStatsEnabled() bool {
+	return atomic.LoadInt32(&poolStatsOn) == 1
+}
+
+// newBuffer counts an allocation by the pool, the buffer is counted as retained until get.
+func (c *poolCounters) newBuffer(capacity int) {
+	if poolStatsEnabled() {
+		atomic.AddUint64(&c.news, 1)
+		atomic.AddInt64(&c.retained, int64(capacity))
+	}
+}
+
+func (c *poolCounters) get(capacity int) {
+	if poolStatsEnabled() {
+		atomic.AddUint64(&c.gets, 1)
+		atomic.AddInt64(&c.retained, -int64(capacity))
+	}
+}
+
+// put reports whether a buffer of capacity may be returned to the pool.
+func (c *poolCounters) put(capacity int) bool {
+	max := atomic.LoadInt64(&poolMaxCapacity)
+	keep := max <= 0 || int64(capacity) <= max
+	if poolStatsEnabled() {
+		if keep {
+			atomic.AddUint64(&c.puts, 1)
+			atomic.AddInt64(&c.retained, int64(capacity))
+		} else {
+			atomic.AddUint64(&c.discards, 1)
+		}
+	}
+	return keep
+}
+
+func (c *poolCounters) stat() PoolStat {
+	return PoolStat{
+		Gets:          atomic.LoadUint64(&c.gets),
+		News:          atomic.LoadUint64(&c.news),
+		Puts:          atomic.LoadUint64(&c.puts),
+		Discards:      atomic.LoadUint64(&c.discards),
+		RetainedBytes: atomic.LoadInt64(&c.retained),
+	}
+}
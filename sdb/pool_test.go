@@ -0,0 +1,93 @@
+package sdb_test
+
+import (
+	"expvar"
+	"strings"
+	"testing"
+
+	"github.com/seambiz/seambiz/sdb"
+)
+
+func enablePoolStats(t *testing.T, maxCapacity int) {
+	t.Helper()
+
+	sdb.EnablePoolStats(true)
+	sdb.SetPoolMaxCapacity(maxCapacity)
+	t.Cleanup(func() {
+		sdb.EnablePoolStats(false)
+		sdb.SetPoolMaxCapacity(sdb.DefaultPoolMaxCapacity)
+	})
+}
+
+func TestPoolStats_SQLStatement(t *testing.T) {
+	enablePoolStats(t, 0)
+	before := sdb.GetPoolStats().SQLStatement
+
+	sql := sdb.NewSQLStatement()
+	sql.Append("SELECT 1")
+	_ = sql.Query()
+
+	// buffers taken from the pool may have any size, so discard all of them
+	sdb.SetPoolMaxCapacity(1)
+	big := sdb.NewSQLStatement()
+	big.AppendStr(strings.Repeat("x", 8192))
+	_ = big.Query()
+
+	after := sdb.GetPoolStats().SQLStatement
+	if got := after.Gets - before.Gets; got != 2 {
+		t.Errorf("Gets increased by %d, want %d", got, 2)
+	}
+	if got := after.Puts - before.Puts; got != 1 {
+		t.Errorf("Puts increased by %d, want %d", got, 1)
+	}
+	if got := after.Discards - before.Discards; got != 1 {
+		t.Errorf("Discards increased by %d, want %d", got, 1)
+	}
+}
+
+func TestPoolStats_JsonBuffer(t *testing.T) {
+	enablePoolStats(t, 0)
+	before := sdb.GetPoolStats().JsonBuffer
+
+	buf := sdb.NewJsonBuffer()
+	buf.JSe("small")
+	_ = buf.Bytes()
+
+	sdb.SetPoolMaxCapacity(1)
+	big := sdb.NewJsonBuffer()
+	big.JSe(strings.Repeat("x", 8192))
+	big.Release()
+
+	after := sdb.GetPoolStats().JsonBuffer
+	if got := after.Gets - before.Gets; got != 2 {
+		t.Errorf("Gets increased by %d, want %d", got, 2)
+	}
+	if got := after.Puts - before.Puts; got != 1 {
+		t.Errorf("Puts increased by %d, want %d", got, 1)
+	}
+	if got := after.Discards - before.Discards; got != 1 {
+		t.Errorf("Discards increased by %d, want %d", got, 1)
+	}
+}
+
+func TestPoolStats_Disabled(t *testing.T) {
+	before := sdb.GetPoolStats()
+	sql := sdb.NewSQLStatement()
+	sql.Release()
+	if after := sdb.GetPoolStats(); after != before {
+		t.Errorf("counters changed while disabled: %+v, want %+v", after, before)
+	}
+}
+
+func TestPublishPoolStats(t *testing.T) {
+	if expvar.Get("sdb_pools_test") == nil {
+		sdb.PublishPoolStats("sdb_pools_test")
+	}
+	v := expvar.Get("sdb_pools_test")
+	if v == nil {
+		t.Fatal("pool stats not published")
+	}
+	if !strings.Contains(v.String(), `"SQLStatement"`) {
+		t.Errorf("unexpected expvar value %s", v.String())
+	}
+}
@@ -0,0 +1,335 @@
+package sdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Query operations reported to a QueryHook
+const (
+	OpExec     = "exec"
+	OpQuery    = "query"
+	OpQueryRow = "queryrow"
+	OpBegin    = "begin"
+	OpCommit   = "commit"
+	OpRollback = "rollback"
+)
+
+// QueryEvent describes a statement run by LogDB or LogTx
+type QueryEvent struct {
+	// Op one of the Op constants
+	Op    string
+	Query string
+	Args  []interface{}
+	// Tx is set for statements inside a transaction
+	Tx bool
+	// Start of the statement
+	Start time.Time
+	// Duration until the driver returned. For queries the rows are not yet read.
+	Duration time.Duration
+	// RowsAffected by exec, -1 if unknown
+	RowsAffected int64
+	Err          error
+}
+
+// QueryHook is called before and after every statement, e.g. for tracing or metrics.
+// The context returned by BeforeQuery is passed to the database and to AfterQuery.
+type QueryHook interface {
+	BeforeQuery(ctx context.Context, e *QueryEvent) context.Context
+	AfterQuery(ctx context.Context, e *QueryEvent)
+}
+
+// LogDB wraps a *sql.DB and reports every statement to its hooks
+type LogDB struct {
+	db    *sql.DB
+	hooks []QueryHook
+}
+
+// NewLogDB returns db wrapped with hooks, typically a QueryLogger.
+func NewLogDB(db *sql.DB, hooks ...QueryHook) *LogDB {
+	return &LogDB{db: db, hooks: hooks}
+}
+
+// DB returns the wrapped database
+func (l *LogDB) DB() *sql.DB {
+	return l.db
+}
+
+// Exec see sql.DB.Exec
+func (l *LogDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return l.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext see sql.DB.ExecContext
+func (l *LogDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return runExec(ctx, l.hooks, false, l.db.ExecContext, query, args)
+}
+
+// Query see sql.DB.Query
+func (l *LogDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return l.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext see sql.DB.QueryContext
+func (l *LogDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return runQuery(ctx, l.hooks, false, l.db.QueryContext, query, args)
+}
+
+// QueryRow see sql.DB.QueryRow
+func (l *LogDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return l.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext see sql.DB.QueryRowContext. Errors are only reported, if the driver
+// fails immediately, not when scanning.
+func (l *LogDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return runQueryRow(ctx, l.hooks, false, l.db.QueryRowContext, query, args)
+}
+
+// Begin see sql.DB.Begin
+func (l *LogDB) Begin() (*LogTx, error) {
+	return l.BeginTx(context.Background(), nil)
+}
+
+// BeginTx see sql.DB.BeginTx. The transaction reports to the same hooks,
+// Commit and Rollback with ctx.
+func (l *LogDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*LogTx, error) {
+	e := &QueryEvent{Op: OpBegin, RowsAffected: -1}
+	hookCtx := beforeQuery(ctx, l.hooks, e)
+	tx, err := l.db.BeginTx(hookCtx, opts)
+	afterQuery(hookCtx, l.hooks, e, err)
+	if err != nil {
+		return nil, err
+	}
+	return &LogTx{tx: tx, hooks: l.hooks, ctx: ctx}, nil
+}
+
+// LogTx wraps a *sql.Tx and reports every statement to its hooks
+type LogTx struct {
+	tx    *sql.Tx
+	hooks []QueryHook
+	// ctx of BeginTx passed to the hooks of Commit and Rollback
+	ctx context.Context
+}
+
+// Tx returns the wrapped transaction
+func (l *LogTx) Tx() *sql.Tx {
+	return l.tx
+}
+
+// Exec see sql.Tx.Exec
+func (l *LogTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return l.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext see sql.Tx.ExecContext
+func (l *LogTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return runExec(ctx, l.hooks, true, l.tx.ExecContext, query, args)
+}
+
+// Query see sql.Tx.Query
+func (l *LogTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return l.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext see sql.Tx.QueryContext
+func (l *LogTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return runQuery(ctx, l.hooks, true, l.tx.QueryContext, query, args)
+}
+
+// QueryRow see sql.Tx.QueryRow
+func (l *LogTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return l.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext see LogDB.QueryRowContext
+func (l *LogTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return runQueryRow(ctx, l.hooks, true, l.tx.QueryRowContext, query, args)
+}
+
+// Commit see sql.Tx.Commit
+func (l *LogTx) Commit() error {
+	return l.finish(OpCommit, l.tx.Commit)
+}
+
+// Rollback see sql.Tx.Rollback
+func (l *LogTx) Rollback() error {
+	return l.finish(OpRollback, l.tx.Rollback)
+}
+
+func (l *LogTx) finish(op string, fn func() error) error {
+	e := &QueryEvent{Op: op, Tx: true, RowsAffected: -1}
+	ctx := beforeQuery(l.ctx, l.hooks, e)
+	err := fn()
+	afterQuery(ctx, l.hooks, e, err)
+	return err
+}
+
+func beforeQuery(ctx context.Context, hooks []QueryHook, e *QueryEvent) context.Context {
+	for _, h := range hooks {
+		ctx = h.BeforeQuery(ctx, e)
+	}
+	e.Start = time.Now()
+	return ctx
+}
+
+func afterQuery(ctx context.Context, hooks []QueryHook, e *QueryEvent, err error) {
+	e.Duration = time.Since(e.Start)
+	e.Err = err
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].AfterQuery(ctx, e)
+	}
+}
+
+func runExec(ctx context.Context, hooks []QueryHook, tx bool,
+	exec func(context.Context, string, ...interface{}) (sql.Result, error), query string, args []interface{}) (sql.Result, error) {
+	e := &QueryEvent{Op: OpExec, Query: query, Args: args, Tx: tx, RowsAffected: -1}
+	ctx = beforeQuery(ctx, hooks, e)
+	res, err := exec(ctx, query, args...)
+	if err == nil {
+		if n, rerr := res.RowsAffected(); rerr == nil {
+			e.RowsAffected = n
+		}
+	}
+	afterQuery(ctx, hooks, e, err)
+	return res, err
+}
+
+func runQuery(ctx context.Context, hooks []QueryHook, tx bool,
+	query func(context.Context, string, ...interface{}) (*sql.Rows, error), q string, args []interface{}) (*sql.Rows, error) {
+	e := &QueryEvent{Op: OpQuery, Query: q, Args: args, Tx: tx, RowsAffected: -1}
+	ctx = beforeQuery(ctx, hooks, e)
+	rows, err := query(ctx, q, args...)
+	afterQuery(ctx, hooks, e, err)
+	return rows, err
+}
+
+func runQueryRow(ctx context.Context, hooks []QueryHook, tx bool,
+	query func(context.Context, string, ...interface{}) *sql.Row, q string, args []interface{}) *sql.Row {
+	e := &QueryEvent{Op: OpQueryRow, Query: q, Args: args, Tx: tx, RowsAffected: -1}
+	ctx = beforeQuery(ctx, hooks, e)
+	row := query(ctx, q, args...)
+	err := row.Err()
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+	afterQuery(ctx, hooks, e, err)
+	return row
+}
+
+// QueryLogger is a QueryHook writing statements to zerolog
+type QueryLogger struct {
+	// Logger defaults to the global zerolog logger
+	Logger *zerolog.Logger
+	// Level of successful statements, default zerolog.DebugLevel. Failed statements are logged as errors.
+	Level zerolog.Level
+	// SlowThreshold statements taking longer are logged as warnings with slow=true, 0 disables it
+	SlowThreshold time.Duration
+	// Redact renders a bind arg for the log, default RedactArg
+	Redact func(arg interface{}) interface{}
+}
+
+// RedactArg keeps numbers, booleans, times and NULL and replaces strings and bytes by their
+// length. driver.Valuer values and pointers are resolved first, all other values are replaced
+// by <redacted>.
+func RedactArg(arg interface{}) interface{} {
+	if valuer, ok := arg.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(arg); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		v, err := valuer.Value()
+		if err != nil {
+			return "<redacted>"
+		}
+		if _, loop := v.(driver.Valuer); loop {
+			return "<redacted>"
+		}
+		return RedactArg(v)
+	}
+
+	rv := reflect.ValueOf(arg)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.String:
+		return "<redacted " + strconv.Itoa(rv.Len()) + " chars>"
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return "<redacted " + strconv.Itoa(rv.Len()) + " bytes>"
+		}
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return rv.Interface()
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t
+	}
+	return "<redacted>"
+}
+
+// BeforeQuery implements QueryHook
+func (q *QueryLogger) BeforeQuery(ctx context.Context, e *QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery implements QueryHook
+func (q *QueryLogger) AfterQuery(ctx context.Context, e *QueryEvent) {
+	logger := q.Logger
+	if logger == nil {
+		logger = &log.Logger
+	}
+
+	slow := q.SlowThreshold > 0 && e.Duration >= q.SlowThreshold
+	var ev *zerolog.Event
+	switch {
+	case e.Err != nil:
+		ev = logger.Error().Err(e.Err)
+	case slow:
+		ev = logger.Warn().Bool("slow", true)
+	default:
+		ev = logger.WithLevel(q.Level)
+	}
+	if ev == nil {
+		return
+	}
+
+	ev = ev.Str("op", e.Op).Dur("duration", e.Duration)
+	if e.Query != "" {
+		ev = ev.Str("query", e.Query)
+	}
+	if len(e.Args) > 0 {
+		redact := q.Redact
+		if redact == nil {
+			redact = RedactArg
+		}
+		args := make([]interface{}, len(e.Args))
+		for i, a := range e.Args {
+			args[i] = redact(a)
+		}
+		ev = ev.Interface("args", args)
+	}
+	if e.RowsAffected >= 0 {
+		ev = ev.Int64("rows_affected", e.RowsAffected)
+	}
+	if e.Tx {
+		ev = ev.Bool("tx", true)
+	}
+	ev.Msg("sql")
+}
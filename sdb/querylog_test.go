@@ -0,0 +1,250 @@
+package sdb_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/seambiz/seambiz/sdb"
+)
+
+type ctxKey struct{}
+
+// recordingHook records all events and checks the context of BeforeQuery reaches AfterQuery
+type recordingHook struct {
+	t      *testing.T
+	events []sdb.QueryEvent
+}
+
+func (h *recordingHook) BeforeQuery(ctx context.Context, e *sdb.QueryEvent) context.Context {
+	return context.WithValue(ctx, ctxKey{}, e.Op)
+}
+
+func (h *recordingHook) AfterQuery(ctx context.Context, e *sdb.QueryEvent) {
+	if got := ctx.Value(ctxKey{}); got != e.Op {
+		h.t.Errorf("context value = %v, want %v", got, e.Op)
+	}
+	h.events = append(h.events, *e)
+}
+
+func newTestQueryLogger(out *bytes.Buffer, slow time.Duration) *sdb.QueryLogger {
+	logger := zerolog.New(out)
+	return &sdb.QueryLogger{Logger: &logger, SlowThreshold: slow}
+}
+
+func decodeLogLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	dec := json.NewDecoder(out)
+	for dec.More() {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestQueryLogger_Exec(t *testing.T) {
+	var out bytes.Buffer
+	db := sdb.NewLogDB(openFakeDB(t, &fakeResult{}), newTestQueryLogger(&out, 0))
+
+	if _, err := db.Exec("UPDATE t SET name = ? WHERE id = ?", "secret", 7); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("FAIL"); err == nil {
+		t.Fatal("expected exec error")
+	}
+
+	lines := decodeLogLines(t, &out)
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want %d", len(lines), 2)
+	}
+
+	ok := lines[0]
+	if ok["level"] != "debug" || ok["op"] != "exec" || ok["query"] != "UPDATE t SET name = ? WHERE id = ?" {
+		t.Errorf("unexpected log line %v", ok)
+	}
+	if ok["rows_affected"] != float64(1) {
+		t.Errorf("rows_affected = %v, want %v", ok["rows_affected"], 1)
+	}
+	args, _ := ok["args"].([]interface{})
+	if len(args) != 2 || args[0] != "<redacted 6 chars>" || args[1] != float64(7) {
+		t.Errorf("args = %v, want redacted string and 7", ok["args"])
+	}
+	if _, found := ok["duration"]; !found {
+		t.Error("duration missing")
+	}
+
+	failed := lines[1]
+	if failed["level"] != "error" || failed["error"] != "exec failed" {
+		t.Errorf("unexpected log line %v", failed)
+	}
+	if _, found := failed["rows_affected"]; found {
+		t.Error("rows_affected logged for failed exec")
+	}
+}
+
+func TestQueryLogger_Slow(t *testing.T) {
+	var out bytes.Buffer
+	res := &fakeResult{columns: []string{"id"}, types: []string{"INT"}, rows: [][]driver.Value{{int64(1)}}}
+	db := sdb.NewLogDB(openFakeDB(t, res), newTestQueryLogger(&out, time.Nanosecond))
+
+	rows, err := db.Query("SELECT id FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	lines := decodeLogLines(t, &out)
+	if len(lines) != 1 || lines[0]["level"] != "warn" || lines[0]["slow"] != true {
+		t.Errorf("unexpected log lines %v", lines)
+	}
+}
+
+func TestQueryLogger_CustomRedact(t *testing.T) {
+	var out bytes.Buffer
+	logger := newTestQueryLogger(&out, 0)
+	logger.Level = zerolog.InfoLevel
+	logger.Redact = func(arg interface{}) interface{} { return "x" }
+	db := sdb.NewLogDB(openFakeDB(t, &fakeResult{}), logger)
+
+	if _, err := db.Exec("DELETE FROM t WHERE id = ?", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := decodeLogLines(t, &out)
+	if len(lines) != 1 || lines[0]["level"] != "info" {
+		t.Fatalf("unexpected log lines %v", lines)
+	}
+	if args, _ := lines[0]["args"].([]interface{}); len(args) != 1 || args[0] != "x" {
+		t.Errorf("args = %v, want [x]", lines[0]["args"])
+	}
+}
+
+func TestLogDB_Hooks(t *testing.T) {
+	res := &fakeResult{columns: []string{"id"}, types: []string{"INT"}, rows: [][]driver.Value{{int64(1)}}}
+	hook := &recordingHook{t: t}
+	db := sdb.NewLogDB(openFakeDB(t, res), hook)
+
+	var id int
+	if err := db.QueryRow("SELECT id FROM t").Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec("INSERT INTO t VALUES (?)", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		op string
+		tx bool
+	}{
+		{op: sdb.OpQueryRow},
+		{op: sdb.OpBegin},
+		{op: sdb.OpExec, tx: true},
+		{op: sdb.OpCommit, tx: true},
+	}
+	if len(hook.events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(hook.events), len(want))
+	}
+	for i, w := range want {
+		e := hook.events[i]
+		if e.Op != w.op || e.Tx != w.tx || e.Err != nil || e.Start.IsZero() {
+			t.Errorf("event %d = %+v, want op %s tx %v", i, e, w.op, w.tx)
+		}
+	}
+	if e := hook.events[2]; e.RowsAffected != 1 || len(e.Args) != 1 {
+		t.Errorf("exec event = %+v, want 1 row affected and 1 arg", e)
+	}
+	if got := res.executed(); len(got) != 1 || got[0] != "INSERT INTO t VALUES (?)" {
+		t.Errorf("executed %v", got)
+	}
+}
+
+type requestKey struct{}
+
+// requestHook records the request value of the contexts passed to BeforeQuery
+type requestHook struct {
+	requests map[string]interface{}
+}
+
+func (h *requestHook) BeforeQuery(ctx context.Context, e *sdb.QueryEvent) context.Context {
+	h.requests[e.Op] = ctx.Value(requestKey{})
+	return ctx
+}
+
+func (h *requestHook) AfterQuery(ctx context.Context, e *sdb.QueryEvent) {}
+
+func TestLogTx_Context(t *testing.T) {
+	hook := &requestHook{requests: map[string]interface{}{}}
+	db := sdb.NewLogDB(openFakeDB(t, &fakeResult{}), hook)
+
+	for _, op := range []string{sdb.OpCommit, sdb.OpRollback} {
+		ctx := context.WithValue(context.Background(), requestKey{}, op)
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op == sdb.OpCommit {
+			err = tx.Commit()
+		} else {
+			err = tx.Rollback()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hook.requests[op]; got != op {
+			t.Errorf("%s hook got request %v, want %v", op, got, op)
+		}
+	}
+}
+
+type password string
+
+func TestRedactArg(t *testing.T) {
+	s := "abc"
+	n := 5
+	now := time.Now()
+	tests := []struct {
+		name string
+		in   interface{}
+		want interface{}
+	}{
+		{name: "string", in: "secret", want: "<redacted 6 chars>"},
+		{name: "bytes", in: []byte{1, 2}, want: "<redacted 2 bytes>"},
+		{name: "string pointer", in: &s, want: "<redacted 3 chars>"},
+		{name: "int", in: 42, want: 42},
+		{name: "bool", in: true, want: true},
+		{name: "time", in: now, want: now},
+		{name: "nil", in: nil, want: nil},
+		{name: "null string", in: sql.NullString{String: "secret", Valid: true}, want: "<redacted 6 chars>"},
+		{name: "invalid null string", in: sql.NullString{}, want: nil},
+		{name: "null int", in: sql.NullInt64{Int64: 7, Valid: true}, want: int64(7)},
+		{name: "nil valuer pointer", in: (*sql.NullString)(nil), want: nil},
+		{name: "int pointer", in: &n, want: 5},
+		{name: "named string type", in: password("hunter2"), want: "<redacted 7 chars>"},
+		{name: "struct", in: struct{ Token string }{"x"}, want: "<redacted>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sdb.RedactArg(tt.in); got != tt.want {
+				t.Errorf("RedactArg(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
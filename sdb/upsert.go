@@ -33,11 +33,14 @@ type UpsertStatement struct {
 
 func (u *UpsertStatement) appendOnDuplicateKey() {
 	if !u.appended && u.recordSet {
-		if u.sql.buffer[len(u.sql.buffer)-2] == ',' {
-			u.sql.buffer[len(u.sql.buffer)-2] = "\x00"[0]
+		// remove the separator after the last record
+		if n := len(u.sql.buffer); u.sql.buffer[n-2] == ',' {
+			u.sql.buffer = append(u.sql.buffer[:n-2], ' ')
+		}
+		if u.onduplicatekeyupdate != "" {
+			u.sql.Append("ON DUPLICATE KEY UPDATE")
+			u.sql.Append(u.onduplicatekeyupdate)
 		}
-		u.sql.Append(" ON DUPLICATE KEY UPDATE")
-		u.sql.Append(u.onduplicatekeyupdate)
 		u.appended = true
 	}
 }
@@ -63,13 +66,13 @@ func (u *UpsertStatement) InsertInto(table string) {
 	u.sql.Append(table)
 }
 
-// Columns to be inserted
+// Columns to be inserted, backticks in the names are escaped
 func (u *UpsertStatement) Columns(cols ...string) {
 	u.columns = cols
 	u.sql.Append("(")
 
 	for i, col := range cols {
-		u.sql.Append("`" + col + "`")
+		u.sql.Append("`" + strings.ReplaceAll(col, "`", "``") + "`")
 		if i < len(cols)-1 {
 			u.sql.Append(",")
 		}
@@ -96,7 +99,8 @@ func (u *UpsertStatement) ColumnsByStruct(v interface{}) {
 	u.Columns(cols...)
 }
 
-// OnDuplicateKeyUpdate what to do
+// OnDuplicateKeyUpdate what to do. Without assignments the clause is omitted and the
+// statement is a plain INSERT.
 func (u *UpsertStatement) OnDuplicateKeyUpdate(sqls []string) {
 	u.onduplicatekeyupdate = strings.Join(sqls, ",")
 }
@@ -111,15 +115,7 @@ func (u *UpsertStatement) Record(values interface{}) {
 	u.sql.Append("(")
 
 	for i, col := range u.columns {
-		if reflect.ValueOf(m[col]).Kind() == reflect.Ptr {
-			if reflect.ValueOf(m[col]).IsNil() {
-				u.sql.Append("NULL")
-			} else {
-				u.sql.Append("'" + EscapeString(fmt.Sprint(reflect.Indirect(reflect.ValueOf(m[col])))) + "'")
-			}
-		} else {
-			u.sql.Append("'" + EscapeString(fmt.Sprint(m[col])) + "'")
-		}
+		u.sql.Append(sqlLiteral(m[col]))
 
 		if i < len(u.columns)-1 {
 			u.sql.Append(",")
@@ -128,3 +124,32 @@ func (u *UpsertStatement) Record(values interface{}) {
 
 	u.sql.Append("),")
 }
+
+// RecordValues adds a record with one value per column in the order of Columns.
+func (u *UpsertStatement) RecordValues(values ...interface{}) {
+	u.recordSet = true
+
+	u.sql.Append("(")
+
+	for i, v := range values {
+		u.sql.Append(sqlLiteral(v))
+
+		if i < len(values)-1 {
+			u.sql.Append(",")
+		}
+	}
+
+	u.sql.Append("),")
+}
+
+// sqlLiteral renders v as escaped string literal. nil and nil pointers are rendered as NULL.
+func sqlLiteral(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if v == nil || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "NULL"
+	}
+	if rv.Kind() == reflect.Ptr {
+		v = reflect.Indirect(rv).Interface()
+	}
+	return "'" + EscapeString(fmt.Sprint(v)) + "'"
+}
@@ -52,3 +52,69 @@ func TestUpsertStatement_Record(t *testing.T) {
 		})
 	}
 }
+
+func TestUpsertStatement_RecordValues(t *testing.T) {
+	s := "x"
+	u := &UpsertStatement{}
+	u.InsertInto("t")
+	u.Columns("a", "b", "c")
+	u.RecordValues(1, nil, &s)
+	u.RecordValues("it's", (*int)(nil), 2.5)
+
+	got := u.Query()
+	want := "INSERT INTO t ( `a` , `b` , `c` ) VALUES  ( '1' , NULL , 'x' ), ( 'it\\'s' , NULL , '2.5' ) "
+	if got != want {
+		t.Errorf("got '%s', want '%s'", got, want)
+	}
+}
+
+func TestSqlLiteral(t *testing.T) {
+	s := "a'b"
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{name: "nil", in: nil, want: "NULL"},
+		{name: "nil pointer", in: (*string)(nil), want: "NULL"},
+		{name: "string pointer", in: &s, want: `'a\'b'`},
+		{name: "int", in: 1, want: "'1'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqlLiteral(tt.in); got != tt.want {
+				t.Errorf("sqlLiteral(%v) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertStatement_OnDuplicateKeyUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		sqls []string
+		want string
+	}{
+		{name: "without update", sqls: nil, want: "INSERT INTO t ( `a` ) VALUES  ( '1' ), ( '2' ) "},
+		{name: "empty update", sqls: []string{}, want: "INSERT INTO t ( `a` ) VALUES  ( '1' ), ( '2' ) "},
+		{
+			name: "update",
+			sqls: []string{"a=VALUES(a)"},
+			want: "INSERT INTO t ( `a` ) VALUES  ( '1' ), ( '2' ) ON DUPLICATE KEY UPDATE a=VALUES(a) ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &UpsertStatement{}
+			u.InsertInto("t")
+			u.Columns("a")
+			u.OnDuplicateKeyUpdate(tt.sqls)
+			u.RecordValues(1)
+			u.RecordValues(2)
+
+			if got := u.Query(); got != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,12 @@ import "time"
 
 const TzGermany = "Europe/Berlin"
 
+// DateLayout german date notation
+const DateLayout = "02.01.2006"
+
+// DateTimeLayout german date and time notation
+const DateTimeLayout = "02.01.2006 15:04:05"
+
 // Now return unix timestamp as uint32
 func Now() uint {
 	t := time.Now().Unix()
@@ -15,9 +21,14 @@ func endOfDay(t *time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), t.Location())
 }
 
+// EndOfDay returns the last nanosecond of the day of t
+func EndOfDay(t time.Time) time.Time {
+	return endOfDay(&t)
+}
+
 // ParseDate parses german date format and returns unix timestamp
 func ParseDate(datum string, endofday bool) (uint, error) {
-	t, err := time.Parse("02.01.2006", datum)
+	t, err := time.Parse(DateLayout, datum)
 	if err != nil {
 		return 0, err
 	}
@@ -31,7 +42,7 @@ func ParseDate(datum string, endofday bool) (uint, error) {
 
 // ParseDateOnly returns date part as integer
 func ParseDateOnly(datum string) (int, error) {
-	t, err := time.Parse("02.01.2006", datum)
+	t, err := time.Parse(DateLayout, datum)
 	if err != nil {
 		return 0, err
 	}
@@ -48,18 +59,18 @@ func Format(format string, t uint) string {
 // FormatDate unix timestamp in german date notation
 func FormatDate(t uint) string {
 	date := In(t, TzGermany)
-	return date.Format("02.01.2006")
+	return date.Format(DateLayout)
 }
 
 // FormatFull unix timestamp into german date and time notation
 func FormatFull(t uint) string {
 	date := In(t, TzGermany)
-	return date.Format("02.01.2006 15:04:05")
+	return date.Format(DateTimeLayout)
 }
 
 // FormatTime time.Time to german date notation
 func FormatTime(t *time.Time) string {
-	return t.Format("02.01.2006")
+	return t.Format(DateLayout)
 }
 
 // FormatIn customer formatting with respective timezone
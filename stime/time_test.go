@@ -356,3 +356,15 @@ func TestIn(t *testing.T) {
 		})
 	}
 }
+
+func TestEndOfDay(t *testing.T) {
+	loc, err := time.LoadLocation(stime.TzGermany)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := time.Date(2020, 3, 29, 10, 0, 0, 0, loc)
+	want := time.Date(2020, 3, 29, 23, 59, 59, 999999999, loc)
+	if got := stime.EndOfDay(in); !got.Equal(want) {
+		t.Errorf("EndOfDay() = %v, want %v", got, want)
+	}
+}